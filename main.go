@@ -9,19 +9,50 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"time"
 )
 
+const pkgListPath = "output/packages.txt"
+
 func main() {
 	debugFlag := flag.Bool("debug", false, "only for debug")
 	opggFlag := flag.Bool("opgg", false, "Fetch & generate data from op.gg")
 	mbFlag := flag.Bool("mb", false, "Fetch & generate murderbridge.com")
+	mbVersions := flag.String("mb-versions", "", "Comma separated murderbridge versions, latest if empty")
+	mbScorer := flag.String("mb-scorer", mb.DefaultScorer, "Scorer for murderbridge items & runes: default, wilson or bayes")
 	laFlag := flag.Bool("la", false, "Fetch & generate lolalytics.com")
+	laTiers := flag.String("la-tiers", la.DefaultTier, "Comma separated lolalytics rank tiers, e.g. gold_plus,diamond_plus")
+	laRegion := flag.String("la-region", la.DefaultRegion, "lolalytics region, e.g. all, kr, euw, na")
+	laQueue := flag.String("la-queue", la.DefaultQueue, "lolalytics ranked queue, solo or flex")
 	fetchAll := flag.Bool("a", false, "Fetch & generate data from all available sources")
 
 	flag.Parse()
 	fmt.Println(os.Args)
 
+	// check options before any import starts
+	scorer, err := mb.GetScorer(*mbScorer)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var laOptions []la.Options
+	for _, tier := range strings.Split(*laTiers, ",") {
+		tier = strings.TrimSpace(tier)
+		for _, opts := range []la.Options{
+			{Tier: tier, Region: *laRegion, Queue: *laQueue, Debug: *debugFlag},
+			{Aram: true, Tier: tier, Region: *laRegion, Debug: *debugFlag},
+		} {
+			if err := opts.Validate(); err != nil {
+				log.Fatal(err)
+			}
+			laOptions = append(laOptions, opts)
+		}
+	}
+
+	// publish scripts only pick packages listed by this run
+	_ = os.MkdirAll("output", os.ModePerm)
+	_ = os.Remove(pkgListPath)
+
 	timestamp := time.Now().UTC().UnixNano() / int64(time.Millisecond)
 	allChampionData, officialVer, err := common.GetChampionList()
 	if err != nil {
@@ -31,6 +62,11 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	runeTree := common.NewRuneTree(allRunes)
+	spellLookUp, err := common.GetSummonerSpells(officialVer)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	var championAliasList = make(map[string]string)
 	for k, v := range allChampionData.Data {
@@ -38,7 +74,7 @@ func main() {
 	}
 
 	ch := make(chan string)
-	var opggRet, mbRet, opggAramRet, laRet, laAramRet string
+	jobs := 0
 
 	if *opggFlag || *fetchAll {
 		fmt.Println("[CMD] Fetch data from op.gg")
@@ -48,39 +84,36 @@ func main() {
 		go func() {
 			ch <- op.ImportAram(allChampionData.Data, championAliasList, officialVer, timestamp, *debugFlag)
 		}()
+		jobs += 2
 	}
 
 	if *mbFlag || *fetchAll {
 		fmt.Println("[CMD] Fetch data from murderbridge.com")
-		go func() {
-			ch <- mb.Import(allChampionData.Data, timestamp, runeLoopUp, allRunes, *debugFlag)
-		}()
+		for _, ver := range strings.Split(*mbVersions, ",") {
+			importer := mb.NewImporter(runeTree, spellLookUp, scorer)
+			importer.Version = strings.TrimSpace(ver)
+			go func() {
+				ch <- importer.Import(allChampionData.Data, timestamp, *debugFlag)
+			}()
+			jobs += 1
+		}
 	}
 
 	if *laFlag || *fetchAll {
 		fmt.Println("[CMD] Fetch data from lolalytics.com")
-		go func() {
-			ch <- la.Import(allChampionData.Data, officialVer, timestamp, runeLoopUp, false, *debugFlag)
-		}()
-		go func() {
-			ch <- la.Import(allChampionData.Data, officialVer, timestamp, runeLoopUp, true, *debugFlag)
-		}()
+		for _, opts := range laOptions {
+			go func(_opts la.Options) {
+				ch <- la.Import(allChampionData.Data, officialVer, timestamp, runeLoopUp, runeTree, spellLookUp, _opts)
+			}(opts)
+			jobs += 1
+		}
 	}
 
-	if *opggFlag || *fetchAll {
-		opggRet = <-ch
-		opggAramRet = <-ch
-		fmt.Println(opggRet)
-		fmt.Println(opggAramRet)
+	for i := 0; i < jobs; i++ {
+		fmt.Println(<-ch)
 	}
-	if *mbFlag || *fetchAll {
-		mbRet = <-ch
-		fmt.Println(mbRet)
-	}
-	if *laFlag || *fetchAll {
-		laRet = <-ch
-		laAramRet = <-ch
-		fmt.Println(laRet)
-		fmt.Println(laAramRet)
+
+	if err := common.SavePkgList(pkgListPath); err != nil {
+		log.Fatal(err)
 	}
 }
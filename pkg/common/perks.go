@@ -0,0 +1,165 @@
+package common
+
+import (
+	"sort"
+)
+
+const (
+	keystoneWeight  = 3
+	subStylesPerKey = 2
+)
+
+type RuneScorer func(id int) float64
+
+type PerkStyleItem struct {
+	Style     int     `json:"style"`
+	Score     float64 `json:"mainScore"`
+	Runes     []int   `json:"runes"`
+	SubStyle  int     `json:"subStyle"`
+	SubScore  float64 `json:"subScore"`
+	SubRunes  []int   `json:"subRunes"`
+	Fragments []int   `json:"fragments"`
+}
+
+func (p PerkStyleItem) PerkIds() []int {
+	var ids []int
+	ids = append(ids, p.Runes...)
+	ids = append(ids, p.SubRunes...)
+	ids = append(ids, p.Fragments...)
+	return ids
+}
+
+type runeStyle struct {
+	id int
+	// rune ids of each slot, keystones first
+	rows [][]int
+}
+
+// RuneTree is an immutable copy of runes reforged, safe to share between goroutines.
+type RuneTree struct {
+	styles    []runeStyle
+	fragments [][]int
+}
+
+func NewRuneTree(allRunes IAllRunes) *RuneTree {
+	tree := RuneTree{}
+	for _, slot := range *allRunes {
+		style := runeStyle{id: slot.Id}
+		for _, s := range slot.Slots {
+			var row []int
+			for _, r := range s.Runes {
+				row = append(row, r.Id)
+			}
+			style.rows = append(style.rows, row)
+		}
+		tree.styles = append(tree.styles, style)
+	}
+
+	for _, ids := range Fragments {
+		tree.fragments = append(tree.fragments, append([]int(nil), ids...))
+	}
+
+	return &tree
+}
+
+// candidate is the best rune of a row, precomputed for each optimization.
+type candidate struct {
+	id    int
+	score float64
+}
+
+func bestOfRow(row []int, score RuneScorer) candidate {
+	var best candidate
+	for i, id := range row {
+		s := score(id)
+		if i == 0 || s > best.score {
+			best = candidate{id: id, score: s}
+		}
+	}
+
+	return best
+}
+
+type subPerk struct {
+	runes []int
+	score float64
+}
+
+// bestSubPerk picks two runes from different non-keystone rows.
+func bestSubPerk(rows []candidate) (subPerk, bool) {
+	var best subPerk
+	found := false
+	for i := 1; i < len(rows); i++ {
+		for j := i + 1; j < len(rows); j++ {
+			s := rows[i].score + rows[j].score
+			if !found || s > best.score {
+				best = subPerk{runes: []int{rows[i].id, rows[j].id}, score: s}
+				found = true
+			}
+		}
+	}
+
+	return best, found
+}
+
+// Optimize evaluates every primary & secondary style combination, each primary keeps its best sub styles.
+func (t *RuneTree) Optimize(score RuneScorer) []PerkStyleItem {
+	var fragments []int
+	for _, row := range t.fragments {
+		fragments = append(fragments, bestOfRow(row, score).id)
+	}
+
+	primaries := make([]PerkStyleItem, len(t.styles))
+	subPerks := make([]subPerk, len(t.styles))
+	hasSubPerk := make([]bool, len(t.styles))
+	for i, style := range t.styles {
+		rows := make([]candidate, len(style.rows))
+		primary := PerkStyleItem{Style: style.id}
+		for j, row := range style.rows {
+			rows[j] = bestOfRow(row, score)
+			primary.Runes = append(primary.Runes, rows[j].id)
+			if j == 0 {
+				primary.Score += keystoneWeight * rows[j].score
+			} else {
+				primary.Score += rows[j].score
+			}
+		}
+
+		primaries[i] = primary
+		subPerks[i], hasSubPerk[i] = bestSubPerk(rows)
+	}
+
+	var result []PerkStyleItem
+	for i, primary := range primaries {
+		var combos []PerkStyleItem
+		for j, sub := range subPerks {
+			if i == j || !hasSubPerk[j] {
+				continue
+			}
+
+			combos = append(combos, PerkStyleItem{
+				Style:     primary.Style,
+				Score:     primary.Score,
+				Runes:     primary.Runes,
+				SubStyle:  t.styles[j].id,
+				SubScore:  sub.score,
+				SubRunes:  sub.runes,
+				Fragments: fragments,
+			})
+		}
+
+		sort.SliceStable(combos, func(a, b int) bool {
+			return combos[a].SubScore > combos[b].SubScore
+		})
+		if len(combos) > subStylesPerKey {
+			combos = combos[:subStylesPerKey]
+		}
+		result = append(result, combos...)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Score+result[i].SubScore > result[j].Score+result[j].SubScore
+	})
+
+	return result
+}
@@ -0,0 +1,174 @@
+package common
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// testRunes builds 5 styles like runes reforged, a keystone row of 4 runes & 3 rows of 3, ids are style*100 + row*10 + i
+func testRunes(t *testing.T) IAllRunes {
+	type runeItem struct {
+		Id int `json:"id"`
+	}
+	type slot struct {
+		Runes []runeItem `json:"runes"`
+	}
+	type style struct {
+		Id    int    `json:"id"`
+		Slots []slot `json:"slots"`
+	}
+
+	var styles []style
+	for s := 80; s < 85; s++ {
+		st := style{Id: s * 100}
+		for row := 0; row < 4; row++ {
+			n := 3
+			if row == 0 {
+				n = 4
+			}
+			var sl slot
+			for i := 0; i < n; i++ {
+				sl.Runes = append(sl.Runes, runeItem{Id: s*100 + row*10 + i})
+			}
+			st.Slots = append(st.Slots, sl)
+		}
+		styles = append(styles, st)
+	}
+
+	body, _ := json.Marshal(styles)
+	var allRunes []RuneSlot
+	if err := json.Unmarshal(body, &allRunes); err != nil {
+		t.Fatal(err)
+	}
+	return &allRunes
+}
+
+// testScorer gives each rune a different score for each seed
+func testScorer(seed int) RuneScorer {
+	return func(id int) float64 {
+		return float64((id*7919 + seed*104729) % 997)
+	}
+}
+
+func checkLegal(t *testing.T, allRunes IAllRunes, p PerkStyleItem) {
+	styles := make(map[int][][]int)
+	for _, s := range *allRunes {
+		for _, sl := range s.Slots {
+			var row []int
+			for _, r := range sl.Runes {
+				row = append(row, r.Id)
+			}
+			styles[s.Id] = append(styles[s.Id], row)
+		}
+	}
+	rowOf := func(style int, id int) int {
+		for i, row := range styles[style] {
+			for _, r := range row {
+				if r == id {
+					return i
+				}
+			}
+		}
+		return -1
+	}
+
+	if p.Style == p.SubStyle {
+		t.Errorf("sub style is the primary style: %+v", p)
+	}
+	if len(p.Runes) != len(styles[p.Style]) {
+		t.Errorf("want a rune of each primary row: %+v", p)
+	}
+	for i, id := range p.Runes {
+		if rowOf(p.Style, id) != i {
+			t.Errorf("rune %d is not in row %d of style %d", id, i, p.Style)
+		}
+	}
+
+	if len(p.SubRunes) != 2 {
+		t.Fatalf("want 2 sub runes: %+v", p)
+	}
+	a, b := rowOf(p.SubStyle, p.SubRunes[0]), rowOf(p.SubStyle, p.SubRunes[1])
+	if a < 1 || b < 1 || a == b {
+		t.Errorf("sub runes %v should come from two different non-keystone rows of style %d", p.SubRunes, p.SubStyle)
+	}
+
+	if len(p.Fragments) != len(Fragments) {
+		t.Fatalf("want a fragment of each row: %+v", p)
+	}
+	for i, id := range p.Fragments {
+		if !containsInt(Fragments[i], id) {
+			t.Errorf("fragment %d is not in row %d", id, i)
+		}
+	}
+}
+
+func containsInt(list []int, target int) bool {
+	for _, v := range list {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+// run with `go test -race`, goroutines share one tree like concurrent imports do
+func TestRuneTreeOptimizeConcurrently(t *testing.T) {
+	allRunes := testRunes(t)
+	tree := NewRuneTree(allRunes)
+	fragments := make([][]int, len(Fragments))
+	for i, row := range Fragments {
+		fragments[i] = append([]int(nil), row...)
+	}
+
+	want := make([][]PerkStyleItem, 8)
+	for seed := range want {
+		want[seed] = tree.Optimize(testScorer(seed))
+	}
+
+	var wg sync.WaitGroup
+	got := make([][]PerkStyleItem, 8*4)
+	for i := range got {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			got[i] = tree.Optimize(testScorer(i % 8))
+		}(i)
+	}
+	wg.Wait()
+
+	for i, result := range got {
+		if len(result) == 0 {
+			t.Fatalf("seed %d: no rune page", i%8)
+		}
+		if !reflect.DeepEqual(result, want[i%8]) {
+			t.Errorf("seed %d: concurrent result differs from sequential one", i%8)
+		}
+
+		perStyle := make(map[int]int)
+		for j, p := range result {
+			checkLegal(t, allRunes, p)
+			perStyle[p.Style] += 1
+			if j > 0 && p.Score+p.SubScore > result[j-1].Score+result[j-1].SubScore {
+				t.Errorf("seed %d: result not sorted by total score", i%8)
+			}
+		}
+		for style, n := range perStyle {
+			if n > subStylesPerKey {
+				t.Errorf("seed %d: style %d has %d sub styles, want at most %d", i%8, style, n, subStylesPerKey)
+			}
+		}
+	}
+
+	if !reflect.DeepEqual(fragments, Fragments) {
+		t.Errorf("Fragments changed by Optimize: %v", Fragments)
+	}
+}
+
+func TestPerkIds(t *testing.T) {
+	p := PerkStyleItem{Runes: []int{1, 2}, SubRunes: []int{3}, Fragments: []int{4}}
+	if got := p.PerkIds(); !reflect.DeepEqual(got, []int{1, 2, 3, 4}) {
+		t.Errorf("PerkIds() = %v", got)
+	}
+}
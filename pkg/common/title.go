@@ -0,0 +1,43 @@
+package common
+
+import (
+	"bytes"
+	"strconv"
+	"text/template"
+)
+
+type statTitle struct {
+	Name      string
+	PickCount int
+	WinRate   float64
+}
+
+// e.g. `Recommended build: Pick 1234, Win Rate 52.10%`
+var statTitleTpl = template.Must(template.New(`statTitle`).Funcs(template.FuncMap{
+	"rate": func(r float64) string {
+		return strconv.FormatFloat(r, 'f', 2, 64) + `%`
+	},
+}).Parse(`{{ .Name }}{{ if .PickCount }}: Pick {{ .PickCount }}{{ end }}{{ if .WinRate }}, Win Rate {{ rate .WinRate }}{{ end }}`))
+
+func StatTitle(name string, pickCount int, winRate float64) string {
+	var buf bytes.Buffer
+	err := statTitleTpl.Execute(&buf, statTitle{
+		Name:      name,
+		PickCount: pickCount,
+		WinRate:   winRate,
+	})
+	if err != nil {
+		return name
+	}
+
+	return buf.String()
+}
+
+func NewStatBlock(name string, pickCount int, pickRate float64, winRate float64) ItemBuildBlockItem {
+	return ItemBuildBlockItem{
+		Type:      StatTitle(name, pickCount, winRate),
+		PickCount: pickCount,
+		PickRate:  pickRate,
+		WinRate:   winRate,
+	}
+}
@@ -0,0 +1,24 @@
+package common
+
+import (
+	"testing"
+)
+
+func TestStatTitle(t *testing.T) {
+	cases := []struct {
+		name      string
+		pickCount int
+		winRate   float64
+		want      string
+	}{
+		{name: `Core`, pickCount: 1234, winRate: 52.1, want: `Core: Pick 1234, Win Rate 52.10%`},
+		{name: `Core`, want: `Core`},
+		{name: `Core`, pickCount: 10, want: `Core: Pick 10`},
+	}
+
+	for _, c := range cases {
+		if got := StatTitle(c.name, c.pickCount, c.winRate); got != c.want {
+			t.Errorf("StatTitle(%q, %d, %v) = %q, want %q", c.name, c.pickCount, c.winRate, got, c.want)
+		}
+	}
+}
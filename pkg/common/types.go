@@ -6,8 +6,11 @@ type BlockItem struct {
 }
 
 type ItemBuildBlockItem struct {
-	Type  string      `json:"type"`
-	Items []BlockItem `json:"items"`
+	Type      string      `json:"type"`
+	Items     []BlockItem `json:"items"`
+	PickCount int         `json:"pickCount,omitempty"`
+	PickRate  float64     `json:"pickRate,omitempty"`
+	WinRate   float64     `json:"winRate,omitempty"`
 }
 
 type ItemBuild struct {
@@ -27,27 +30,98 @@ type RuneItem struct {
 	Alias           string  `json:"alias"`
 	Name            string  `json:"name"`
 	Position        string  `json:"position"`
-	PickCount       int     `json:"pickCount"`
-	WinRate         string  `json:"winRate"`
+	PickCount       int     `json:"pickCount,omitempty"`
+	PickRate        float64 `json:"pickRate,omitempty"`
+	WinRate         float64 `json:"winRate,omitempty"`
 	PrimaryStyleId  int     `json:"primaryStyleId"`
 	SubStyleId      int     `json:"subStyleId"`
 	SelectedPerkIds []int   `json:"selectedPerkIds"`
 	Score           float64 `json:"score"`
 }
 
+type SkillOrder struct {
+	Type      string   `json:"type"`
+	Order     []string `json:"order"`
+	PickCount int      `json:"pickCount"`
+	PickRate  float64  `json:"pickRate"`
+	WinRate   float64  `json:"winRate"`
+}
+
+type MatchupItem struct {
+	Alias    string  `json:"alias"`
+	Name     string  `json:"name"`
+	Position string  `json:"position"`
+	Games    int     `json:"games"`
+	WinRate  float64 `json:"winRate"`
+	Delta    float64 `json:"delta,omitempty"`
+	Counter  string  `json:"counter,omitempty"`
+}
+
+type TrendPoint struct {
+	Date     string  `json:"date"`
+	WinRate  float64 `json:"winRate"`
+	PickRate float64 `json:"pickRate"`
+	BanRate  float64 `json:"banRate"`
+	Games    int     `json:"games"`
+}
+
+type RuneStat struct {
+	Id       int     `json:"id"`
+	PickRate float64 `json:"pickRate"`
+	WinRate  float64 `json:"winRate"`
+	Games    int     `json:"games"`
+}
+
+type DamageProfile struct {
+	Physical float64 `json:"physical"`
+	Magic    float64 `json:"magic"`
+	True     float64 `json:"true"`
+}
+
+type ChampionMeta struct {
+	Tier        string             `json:"tier,omitempty"`
+	Rank        int                `json:"rank,omitempty"`
+	RankTotal   int                `json:"rankTotal,omitempty"`
+	WinRate     float64            `json:"winRate"`
+	PickRate    float64            `json:"pickRate"`
+	BanRate     float64            `json:"banRate"`
+	Damage      *DamageProfile     `json:"damage,omitempty"`
+	Games       int                `json:"games,omitempty"`
+	Adjustments map[string]float64 `json:"adjustments,omitempty"`
+	Stats       map[string]float64 `json:"stats,omitempty"`
+}
+
+type DurationPoint struct {
+	Minute  int     `json:"minute"`
+	WinRate float64 `json:"winRate"`
+	Delta   float64 `json:"delta"`
+	Share   float64 `json:"share"`
+}
+
+type DurationCurve struct {
+	Points []DurationPoint `json:"points"`
+	Label  string          `json:"label"`
+}
+
 type ChampionDataItem struct {
-	Index           int         `json:"index"`
-	Id              string      `json:"id"`
-	Version         string      `json:"version"`
-	OfficialVersion string      `json:"officialVersion"`
-	Timestamp       int64       `json:"timestamp"`
-	Alias           string      `json:"alias"`
-	Name            string      `json:"name"`
-	Position        string      `json:"position"`
-	Skills          []string    `json:"skills"`
-	Spells          []string    `json:"spells"`
-	ItemBuilds      []ItemBuild `json:"itemBuilds"`
-	Runes           []RuneItem  `json:"runes"`
+	Index           int                     `json:"index"`
+	Id              string                  `json:"id"`
+	Version         string                  `json:"version"`
+	OfficialVersion string                  `json:"officialVersion"`
+	Timestamp       int64                   `json:"timestamp"`
+	Alias           string                  `json:"alias"`
+	Name            string                  `json:"name"`
+	Position        string                  `json:"position"`
+	Meta            *ChampionMeta           `json:"meta,omitempty"`
+	Skills          []string                `json:"skills"`
+	Spells          []string                `json:"spells"`
+	SkillOrders     []SkillOrder            `json:"skillOrders,omitempty"`
+	Counters        []MatchupItem           `json:"counters,omitempty"`
+	Trends          map[string][]TrendPoint `json:"trends,omitempty"`
+	Duration        *DurationCurve          `json:"duration,omitempty"`
+	ItemBuilds      []ItemBuild             `json:"itemBuilds"`
+	Runes           []RuneItem              `json:"runes"`
+	RuneStats       []RuneStat              `json:"runeStats,omitempty"`
 }
 
 type ChampionItem struct {
@@ -110,6 +184,10 @@ type PkgInfo struct {
 	Timestamp       int64  `json:"timestamp"`
 	SourceVersion   string `json:"sourceVersion"`
 	OfficialVersion string `json:"officialVersion"`
+	Tier            string `json:"tier"`
+	Region          string `json:"region"`
+	Queue           string `json:"queue"`
+	Scorer          string `json:"scorer"`
 }
 
 type BuildItem struct {
@@ -155,5 +233,18 @@ type RuneSlot struct {
 	} `json:"slots"`
 }
 
+type SummonerSpellItem struct {
+	Id   string `json:"id"`
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+type SummonerSpellResp struct {
+	Type    string                       `json:"type"`
+	Version string                       `json:"version"`
+	Data    map[string]SummonerSpellItem `json:"data"`
+}
+
 type IRuneLookUp map[int]*RespRuneItem
-type IAllRunes *[]RuneSlot
\ No newline at end of file
+type ISpellLookUp map[string]string
+type IAllRunes *[]RuneSlot
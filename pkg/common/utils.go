@@ -13,7 +13,9 @@ import (
 	"path/filepath"
 	"reflect"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
 )
 
 const (
@@ -185,6 +187,8 @@ func GetRunesReforged(version string) (IRuneLookUp, IAllRunes, error) {
 	for _, slot := range resp {
 		for j, s := range slot.Slots {
 			for _, r := range s.Runes {
+				// copy before taking its address, otherwise every entry points at the last rune
+				r := r
 				r.Style = slot.Id
 				r.Slot = j
 				r.Primary = j == 0
@@ -195,6 +199,91 @@ func GetRunesReforged(version string) (IRuneLookUp, IAllRunes, error) {
 	return data, &resp, nil
 }
 
+// GetSummonerSpells maps spell keys to names in the same form as `MatchSpellName`, e.g. `4` -> `flash`
+func GetSummonerSpells(version string) (ISpellLookUp, error) {
+	body, err := MakeRequest(DataDragonUrl + `/cdn/` + version + `/data/en_US/summoner.json`)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp SummonerSpellResp
+	_ = json.Unmarshal(body, &resp)
+
+	data := make(ISpellLookUp)
+	for _, s := range resp.Data {
+		data[s.Key] = strings.ToLower(strings.TrimPrefix(s.Id, `Summoner`))
+	}
+	return data, nil
+}
+
+// SkillLetters accepts both letters & 1-based indexes, e.g. `QEW` or `132`
+func SkillLetters(src string) []string {
+	var letters []string
+	for _, c := range strings.ToUpper(src) {
+		switch {
+		case c >= '1' && c <= '4':
+			letters = append(letters, string("QWER"[c-'1']))
+		case strings.ContainsRune("QWER", c):
+			letters = append(letters, string(c))
+		}
+	}
+
+	return letters
+}
+
+// SkillPriority returns skills in the order they are maxed, e.g. [Q, E, W]
+func SkillPriority(order []string) []string {
+	points := make(map[string]int)
+	var priority []string
+	for _, s := range order {
+		if s == `R` {
+			continue
+		}
+
+		points[s] += 1
+		if points[s] == 5 {
+			priority = append(priority, s)
+		}
+	}
+
+	// the rest by points
+	var rest []string
+	for _, s := range []string{`Q`, `W`, `E`} {
+		if points[s] > 0 && points[s] < 5 {
+			rest = append(rest, s)
+		}
+	}
+	sort.SliceStable(rest, func(i, j int) bool {
+		return points[rest[i]] > points[rest[j]]
+	})
+
+	return append(priority, rest...)
+}
+
+// SpellNames maps keys of a spell pair, e.g. `4_14` -> [flash, dot]
+func SpellNames(id string, spellLookUp ISpellLookUp) []string {
+	keys := strings.FieldsFunc(id, func(r rune) bool {
+		return r < '0' || r > '9'
+	})
+
+	var spells []string
+	for _, k := range keys {
+		if name, ok := spellLookUp[k]; ok {
+			spells = append(spells, name)
+		}
+	}
+
+	return spells
+}
+
+// ShrinkWinRate moves win rate of `games` towards `priorWinRate` as if `priorGames` were played at it
+func ShrinkWinRate(winRate float64, games float64, priorWinRate float64, priorGames float64) float64 {
+	if games+priorGames == 0 {
+		return priorWinRate
+	}
+	return (winRate*games + priorWinRate*priorGames) / (games + priorGames)
+}
+
 func GetKeys(v interface{}) []string {
 	var keys []string
 	value := reflect.ValueOf(v)
@@ -213,20 +302,56 @@ func GetPrimaryIdForRune(id int, runeLookUp IRuneLookUp) int {
 	return runeLookUp[id].Style
 }
 
-func Write2Folder(result [][]ChampionDataItem, pkgName string, timestamp int64, sourceVersion string, officialVer string) {
-	outputPath := filepath.Join(".", "output", pkgName)
+func Write2Folder(result [][]ChampionDataItem, info PkgInfo) {
+	outputPath := filepath.Join(".", "output", info.PkgName)
 	_ = os.MkdirAll(outputPath, os.ModePerm)
 
 	for _, data := range result {
+		if len(data) == 0 || len(data[0].Alias) == 0 {
+			fmt.Printf("[%s] Skipped champion data without alias\n", info.PkgName)
+			continue
+		}
+
 		fileName := outputPath + "/" + data[0].Alias + ".json"
 		_ = SaveJSON(fileName, data)
 	}
 
-	pkg, _ := GenPkgInfo("tpl/package.json", PkgInfo{
-		Timestamp:       timestamp,
-		SourceVersion:   sourceVersion,
-		OfficialVersion: officialVer,
-		PkgName:         pkgName,
-	})
-	_ = ioutil.WriteFile("output/"+pkgName+"/package.json", []byte(pkg), 0644)
+	_ = WritePkgInfo(info)
+}
+
+// packages written in this run, publish scripts read them from `SavePkgList`
+var writtenPkgs = struct {
+	sync.Mutex
+	names []string
+}{}
+
+func WritePkgInfo(info PkgInfo) error {
+	pkg, err := GenPkgInfo("tpl/package.json", info)
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(filepath.Join("output", info.PkgName, "package.json"), []byte(pkg), 0644)
+	if err != nil {
+		return err
+	}
+
+	writtenPkgs.Lock()
+	writtenPkgs.names = NoRepeatPush(info.PkgName, writtenPkgs.names)
+	writtenPkgs.Unlock()
+	return nil
+}
+
+// SavePkgList writes names of packages written in this run, one per line
+func SavePkgList(fileName string) error {
+	writtenPkgs.Lock()
+	names := append([]string(nil), writtenPkgs.names...)
+	writtenPkgs.Unlock()
+
+	sort.Strings(names)
+	content := ""
+	for _, name := range names {
+		content += name + "\n"
+	}
+	return ioutil.WriteFile(fileName, []byte(content), 0644)
 }
@@ -0,0 +1,78 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSkillLetters(t *testing.T) {
+	cases := []struct {
+		src  string
+		want []string
+	}{
+		{src: `QEW`, want: []string{`Q`, `E`, `W`}},
+		{src: `qew`, want: []string{`Q`, `E`, `W`}},
+		{src: `1324`, want: []string{`Q`, `E`, `W`, `R`}},
+		{src: `Q-E-W`, want: []string{`Q`, `E`, `W`}},
+		{src: ``, want: nil},
+	}
+
+	for _, c := range cases {
+		if got := SkillLetters(c.src); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("SkillLetters(%q) = %v, want %v", c.src, got, c.want)
+		}
+	}
+}
+
+func TestSkillPriority(t *testing.T) {
+	cases := []struct {
+		order string
+		want  []string
+	}{
+		{order: `QWEQQRQEQEREEWWRWW`, want: []string{`Q`, `E`, `W`}},
+		{order: `QEWEERE`, want: []string{`E`, `Q`, `W`}},
+		{order: `R`, want: nil},
+	}
+
+	for _, c := range cases {
+		if got := SkillPriority(SkillLetters(c.order)); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("SkillPriority(%q) = %v, want %v", c.order, got, c.want)
+		}
+	}
+}
+
+func TestSpellNames(t *testing.T) {
+	lookUp := ISpellLookUp{`4`: `flash`, `14`: `dot`}
+	cases := []struct {
+		id   string
+		want []string
+	}{
+		{id: `4_14`, want: []string{`flash`, `dot`}},
+		{id: `[14,4]`, want: []string{`dot`, `flash`}},
+		{id: `4_99`, want: []string{`flash`}},
+	}
+
+	for _, c := range cases {
+		if got := SpellNames(c.id, lookUp); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("SpellNames(%q) = %v, want %v", c.id, got, c.want)
+		}
+	}
+}
+
+func TestShrinkWinRate(t *testing.T) {
+	cases := []struct {
+		winRate, games, priorWinRate, priorGames float64
+		want                                     float64
+	}{
+		{winRate: 60, games: 100, priorWinRate: 50, priorGames: 100, want: 55},
+		{winRate: 60, games: 0, priorWinRate: 50, priorGames: 100, want: 50},
+		{winRate: 60, games: 100, priorWinRate: 50, priorGames: 0, want: 60},
+		{winRate: 60, games: 0, priorWinRate: 50, priorGames: 0, want: 50},
+	}
+
+	for _, c := range cases {
+		if got := ShrinkWinRate(c.winRate, c.games, c.priorWinRate, c.priorGames); got != c.want {
+			t.Errorf("ShrinkWinRate(%v, %v, %v, %v) = %v, want %v", c.winRate, c.games, c.priorWinRate, c.priorGames, got, c.want)
+		}
+	}
+}
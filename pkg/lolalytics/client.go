@@ -0,0 +1,99 @@
+package lolalytics
+
+import (
+	"data-crawler/pkg/common"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+const (
+	SiteUrl            = "https://lolalytics.com"
+	DefaultApiRevision = "9"
+	aramQueue          = "450"
+)
+
+var (
+	patchReg       = regexp.MustCompile("for Patch (\\d+.\\d+(.\\d+)?)")
+	apiRevisionReg = regexp.MustCompile("ep=champion&p=d&v=(\\d+)")
+)
+
+type Client struct {
+	ApiUrl  string
+	SiteUrl string
+}
+
+func NewClient() *Client {
+	return &Client{
+		ApiUrl:  ApiUrl,
+		SiteUrl: SiteUrl,
+	}
+}
+
+// Discover reads the current patch from a champion build page, and returns a base query for it.
+func (c *Client) Discover(aram bool) (Query, error) {
+	buildUrl := c.SiteUrl + "/lol/rengar/build/"
+	if aram {
+		buildUrl = c.SiteUrl + "/lol/rengar/aram/build/"
+	}
+
+	body, err := common.MakeRequest(buildUrl)
+	if err != nil {
+		return Query{}, err
+	}
+
+	html := string(body)
+	m := patchReg.FindStringSubmatch(html)
+	if m == nil {
+		return Query{}, fmt.Errorf("lolalytics: patch version not found in %s, page format may have changed", buildUrl)
+	}
+
+	q := Query{
+		Ep:    "champion",
+		P:     "d",
+		V:     DefaultApiRevision,
+		Patch: m[1],
+		Queue: queues[DefaultQueue],
+	}
+	if r := apiRevisionReg.FindStringSubmatch(html); r != nil {
+		q.V = r[1]
+	}
+	if aram {
+		q.Queue = aramQueue
+	}
+
+	return q, nil
+}
+
+func (c *Client) TierList(q Query) (*ITierList, error) {
+	// list sort by name
+	body, err := common.MakeRequest(c.ApiUrl + "/tierlist/7/?" + q.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var data ITierList
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, fmt.Errorf("lolalytics: decode tier list failed, %s", err)
+	}
+	if len(data.Cid) == 0 {
+		return nil, errors.New("lolalytics: tier list is empty")
+	}
+
+	return &data, nil
+}
+
+func (c *Client) Mega(q Query) (*IChampionData, error) {
+	body, err := common.MakeRequest(c.ApiUrl + "/mega?" + q.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var data IChampionData
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, fmt.Errorf("lolalytics: decode champion data failed, %s", err)
+	}
+
+	return &data, nil
+}
@@ -0,0 +1,189 @@
+package lolalytics
+
+import (
+	"data-crawler/pkg/common"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	MinimumSampleSize = 100
+	maxBlockItems     = 4
+)
+
+// columns of item rows, e.g. `boots`, `mythicItem`: [id, win rate, pick rate, games]
+const (
+	itemIdCol    = 0
+	itemWrCol    = 1
+	itemPrCol    = 2
+	itemGamesCol = 3
+)
+
+type itemStat struct {
+	ids   []int
+	games float64
+	wr    float64
+	pr    float64
+}
+
+func parseItemRows(rows [][]float64) []itemStat {
+	var stats []itemStat
+	for _, row := range rows {
+		if len(row) <= itemGamesCol {
+			continue
+		}
+
+		stats = append(stats, itemStat{
+			ids:   []int{int(row[itemIdCol])},
+			games: row[itemGamesCol],
+			wr:    row[itemWrCol],
+			pr:    row[itemPrCol],
+		})
+	}
+
+	return stats
+}
+
+// start sets are keyed by `_` joined ids, e.g. `1055_2003`
+func parseStartSets(rows [][]interface{}) []itemStat {
+	var stats []itemStat
+	for _, row := range rows {
+		if len(row) <= itemGamesCol {
+			continue
+		}
+
+		key, ok := row[itemIdCol].(string)
+		if !ok {
+			continue
+		}
+
+		stat := itemStat{}
+		for _, s := range strings.Split(key, "_") {
+			if id, err := strconv.Atoi(s); err == nil {
+				stat.ids = append(stat.ids, id)
+			}
+		}
+		stat.wr, _ = row[itemWrCol].(float64)
+		stat.pr, _ = row[itemPrCol].(float64)
+		stat.games, _ = row[itemGamesCol].(float64)
+		if len(stat.ids) > 0 {
+			stats = append(stats, stat)
+		}
+	}
+
+	return stats
+}
+
+// boot sets are keyed by boots id, with [games, wins], one map for each item slot
+func parseBootSets(sets ...map[string][]int) []itemStat {
+	games := make(map[int]float64)
+	wins := make(map[int]float64)
+	for _, set := range sets {
+		for key, v := range set {
+			id, err := strconv.Atoi(key)
+			if err != nil || len(v) < 2 {
+				continue
+			}
+
+			games[id] += float64(v[0])
+			wins[id] += float64(v[1])
+		}
+	}
+
+	var stats []itemStat
+	for id, n := range games {
+		if n == 0 {
+			continue
+		}
+		stats = append(stats, itemStat{
+			ids:   []int{id},
+			games: n,
+			wr:    wins[id] / n * 100,
+		})
+	}
+
+	return stats
+}
+
+func topByWinRate(stats []itemStat, limit int) []itemStat {
+	var ret []itemStat
+	for _, s := range stats {
+		if s.games >= MinimumSampleSize {
+			ret = append(ret, s)
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].wr > ret[j].wr
+	})
+
+	if len(ret) > limit {
+		return ret[:limit]
+	}
+	return ret
+}
+
+// makeItemStatBlock merges stats into one block, items keep their quantity within a set.
+func makeItemStatBlock(title string, stats []itemStat) common.ItemBuildBlockItem {
+	var games, wins, pr float64
+	var items []common.BlockItem
+	for _, s := range stats {
+		games += s.games
+		wins += s.games * s.wr
+		pr += s.pr
+
+		counts := make(map[int]int)
+		var ids []int
+		for _, id := range s.ids {
+			if counts[id] == 0 {
+				ids = append(ids, id)
+			}
+			counts[id] += 1
+		}
+		for _, id := range ids {
+			items = append(items, common.BlockItem{
+				Id:    strconv.Itoa(id),
+				Count: counts[id],
+			})
+		}
+	}
+
+	var wr float64
+	if games > 0 {
+		wr = wins / games
+	}
+	block := common.NewStatBlock(title, int(games), pr, wr)
+	block.Items = items
+	return block
+}
+
+func makeExtraBlocks(resp *IChampionData) []common.ItemBuildBlockItem {
+	var blocks []common.ItemBuildBlockItem
+
+	for _, set := range topByWinRate(parseStartSets(resp.StartSet), 2) {
+		blocks = append(blocks, makeItemStatBlock("Starting set", []itemStat{set}))
+	}
+
+	if early := topByWinRate(parseItemRows(resp.EarlyItem), maxBlockItems); len(early) > 0 {
+		blocks = append(blocks, makeItemStatBlock("Early items", early))
+	}
+
+	if mythic := topByWinRate(parseItemRows(resp.MythicItem), maxBlockItems); len(mythic) > 0 {
+		blocks = append(blocks, makeItemStatBlock("Mythic items", mythic))
+	}
+
+	boots := parseItemRows(resp.Boots)
+	if len(boots) == 0 {
+		boots = parseBootSets(resp.ItemSets.ItemBootSet1, resp.ItemSets.ItemBootSet2, resp.ItemSets.ItemBootSet3)
+	}
+	if top := topByWinRate(boots, maxBlockItems); len(top) > 0 {
+		blocks = append(blocks, makeItemStatBlock("Boots", top))
+	}
+
+	if winning := topByWinRate(parseItemRows(resp.WinningItem), maxBlockItems); len(winning) > 0 {
+		blocks = append(blocks, makeItemStatBlock("Winning items", winning))
+	}
+
+	return blocks
+}
@@ -2,10 +2,8 @@ package lolalytics
 
 import (
 	"data-crawler/pkg/common"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"regexp"
 	"strconv"
 	"strings"
 	"sync"
@@ -15,30 +13,89 @@ import (
 const (
 	ApiUrl          = "https://apix1.op.lol"
 	MinimumPickRate = 5
+	DefaultTier     = "gold_plus"
 )
 
-var (
-	cidReg   = regexp.MustCompile("&cid=\\d+?&")
-	laneReg  = regexp.MustCompile("&lane=[a-zA-Z]+?&")
-	epReg    = regexp.MustCompile("ep=.*?region=all")
-	tierReg  = regexp.MustCompile("&tier=[a-zA-Z_]+&")
-	patchReg = regexp.MustCompile("for Patch (\\d+.\\d+(.\\d+)?)")
-)
+type Options struct {
+	Aram   bool
+	Tier   string
+	Region string
+	Queue  string
+	Debug  bool
+}
 
-func makeQuery(query string) func(string, string, string, string) string {
-	oldQ := query
-	return func(cid string, lane string, tier string, patch string) string {
-		q := cidReg.ReplaceAllString(oldQ, "&cid="+cid+"&")
-		q = laneReg.ReplaceAllString(q, "&lane="+lane+"&")
-		q = tierReg.ReplaceAllString(q, "&tier="+tier+"&")
-		q = tierReg.ReplaceAllString(q, "&patch="+patch+"&")
-		return q
+// short labels used in build & rune titles
+var tierLabels = map[string]string{
+	"all":              "All",
+	"1trick":           "1-Trick",
+	"challenger":       "C",
+	"grandmaster_plus": "GM+",
+	"master_plus":      "M+",
+	"diamond_plus":     "D+",
+	"diamond":          "D",
+	"platinum_plus":    "P+",
+	"platinum":         "P",
+	"gold_plus":        "G+",
+	"gold":             "G",
+	"silver":           "S",
+	"bronze":           "B",
+	"iron":             "I",
+}
+
+// Validate fills empty tier, region & queue with defaults, and rejects unknown ones
+func (opts *Options) Validate() error {
+	if len(opts.Tier) == 0 {
+		opts.Tier = DefaultTier
+	}
+	if len(opts.Region) == 0 {
+		opts.Region = DefaultRegion
+	}
+	if len(opts.Queue) == 0 && !opts.Aram {
+		opts.Queue = DefaultQueue
+	}
+
+	if _, ok := tierLabels[opts.Tier]; !ok {
+		return fmt.Errorf("lolalytics: unknown tier %s", opts.Tier)
+	}
+	if !common.Includes(opts.Region, regions) {
+		return fmt.Errorf("lolalytics: unknown region %s", opts.Region)
+	}
+	if _, ok := queues[opts.Queue]; !ok && !opts.Aram {
+		return fmt.Errorf("lolalytics: unknown queue %s", opts.Queue)
+	}
+
+	return nil
+}
+
+func getPkgName(opts Options) string {
+	pkgName := `lolalytics`
+	if opts.Aram {
+		pkgName = `lolalytics-aram`
+	}
+	if opts.Tier != DefaultTier {
+		pkgName += `-` + opts.Tier
 	}
+	if opts.Region != DefaultRegion {
+		pkgName += `-` + opts.Region
+	}
+	if !opts.Aram && opts.Queue != DefaultQueue {
+		pkgName += `-` + opts.Queue
+	}
+
+	return pkgName
 }
 
-func getSourceVersion(q string) string {
-	m := patchReg.FindAllStringSubmatch(q, 1)
-	return m[0][1]
+// scopeLabel describes tier, and region & queue if they are not default, e.g. `(G+, KR, Flex)`
+func scopeLabel(opts Options) string {
+	labels := []string{tierLabels[opts.Tier]}
+	if opts.Region != DefaultRegion {
+		labels = append(labels, strings.ToUpper(opts.Region))
+	}
+	if !opts.Aram && opts.Queue != DefaultQueue {
+		labels = append(labels, strings.Title(opts.Queue))
+	}
+
+	return "(" + strings.Join(labels, ", ") + ")"
 }
 
 func getPatchVersion(v string) string {
@@ -48,31 +105,30 @@ func getPatchVersion(v string) string {
 	return strings.Join(versionArr, ".")
 }
 
-func getTierList(q string) (ITierList, error) {
-	var data ITierList
-
-	// list sort by name
-	body, err := common.MakeRequest(ApiUrl + "/tierlist/7/?" + q)
-	if err != nil {
-		return data, err
+func getChampionById(id string, championAliasList map[string]common.ChampionItem) (common.ChampionItem, bool) {
+	for _, champ := range championAliasList {
+		if id == champ.Key {
+			return champ, true
+		}
 	}
 
-	_ = json.Unmarshal(body, &data)
-	return data, nil
+	return common.ChampionItem{}, false
 }
 
-func getChampionById(id string, championAliasList map[string]common.ChampionItem) common.ChampionItem {
-	var ret common.ChampionItem
-	for _, champ := range championAliasList {
-		if id != champ.Key {
+// resolveChampions maps tier list ids to champions, ids unknown to Data Dragon are returned separately.
+func resolveChampions(cIds []string, championAliasList map[string]common.ChampionItem) (map[string]common.ChampionItem, []string) {
+	champions := make(map[string]common.ChampionItem)
+	var unknown []string
+	for _, cid := range cIds {
+		champion, ok := getChampionById(cid, championAliasList)
+		if !ok {
+			unknown = append(unknown, cid)
 			continue
 		}
-
-		ret = champ
-		break
+		champions[cid] = champion
 	}
 
-	return ret
+	return champions, unknown
 }
 
 func makeBlock(title string, set []int) common.ItemBuildBlockItem {
@@ -91,6 +147,12 @@ func makeBlock(title string, set []int) common.ItemBuildBlockItem {
 	return blockItem
 }
 
+func makeStatBlock(title string, set []int, n float64, wr float64) common.ItemBuildBlockItem {
+	blockItem := common.NewStatBlock(title, int(n), 0, wr)
+	blockItem.Items = makeBlock(title, set).Items
+	return blockItem
+}
+
 func extractItemIds(items []IItemN) []int {
 	var ids []int
 	for _, i := range items {
@@ -100,31 +162,50 @@ func extractItemIds(items []IItemN) []int {
 	return ids
 }
 
+// makeSlotBlock sums games of the item options of a slot, win rate is weighted by games
+func makeSlotBlock(title string, items []IItemN) common.ItemBuildBlockItem {
+	var n, wins float64
+	for _, i := range items {
+		n += i.N
+		wins += i.N * i.Wr
+	}
+
+	wr := float64(0)
+	if n > 0 {
+		wr = wins / n
+	}
+	return makeStatBlock(title, extractItemIds(items), n, wr)
+}
+
 func makeBuildBlocksFromSet(data IItems) []common.ItemBuildBlockItem {
 	var blocks []common.ItemBuildBlockItem
-	startingTitle := "Starting items, win rate " + fmt.Sprintf("%.2f%%", data.Start.Wr)
-	startingBlock := makeBlock(startingTitle, data.Start.Set)
+	startingBlock := makeStatBlock("Starting items", data.Start.Set, data.Start.N, data.Start.Wr)
 	blocks = append(blocks, startingBlock)
 
-	coreTitle := "Core items, win rate " + fmt.Sprintf("%.2f%%", data.Core.Wr)
-	coreBlock := makeBlock(coreTitle, data.Core.Set)
+	coreBlock := makeStatBlock("Core items", data.Core.Set, data.Core.N, data.Core.Wr)
 	blocks = append(blocks, coreBlock)
 
-	item4Ids := extractItemIds(data.Item4)
-	item4Block := makeBlock("Item 4", item4Ids)
+	item4Block := makeSlotBlock("Item 4", data.Item4)
 	blocks = append(blocks, item4Block)
 
-	item5Ids := extractItemIds(data.Item5)
-	item5Block := makeBlock("Item 5", item5Ids)
+	item5Block := makeSlotBlock("Item 5", data.Item5)
 	blocks = append(blocks, item5Block)
 
-	item6Ids := extractItemIds(data.Item6)
-	item6Block := makeBlock("Item 6", item6Ids)
+	item6Block := makeSlotBlock("Item 6", data.Item6)
 	blocks = append(blocks, item6Block)
 
 	return blocks
 }
 
+func makeSkillOrder(title string, id int64, n float64, wr float64) common.SkillOrder {
+	return common.SkillOrder{
+		Type:      title,
+		Order:     common.SkillLetters(strconv.FormatInt(id, 10)),
+		PickCount: int(n),
+		WinRate:   wr,
+	}
+}
+
 func concatRuneIds(pri []int, sec []int, mod []int) []int {
 	var ids []int
 	ids = append(ids, pri...)
@@ -133,22 +214,19 @@ func concatRuneIds(pri []int, sec []int, mod []int) []int {
 	return ids
 }
 
-func makeBuild(champion common.ChampionItem, query string, sourceVersion string, officialVer string, timestamp int64, cnt int, fetchMore bool, runeLookUp common.IRuneLookUp, aram bool) (*[]common.ChampionDataItem, error) {
-	body, err := common.MakeRequest(ApiUrl + "/mega?" + query)
-
+func makeBuild(c *Client, champion common.ChampionItem, championAliasList map[string]common.ChampionItem, query Query, sourceVersion string, officialVer string, timestamp int64, cnt int, fetchMore bool, runeLookUp common.IRuneLookUp, runeTree *common.RuneTree, spellLookUp common.ISpellLookUp, opts Options) (*[]common.ChampionDataItem, error) {
+	resp, err := c.Mega(query)
 	if err != nil {
-		fmt.Println("[lolalytics] Fetch champion data failed.", champion.Id)
+		fmt.Println("[lolalytics] Fetch champion data failed.", champion.Id, err)
 		return nil, err
 	}
 
-	var resp IChampionData
-	_ = json.Unmarshal(body, &resp)
 	ID, _ := strconv.Atoi(champion.Key)
 	curLane := resp.Header.Lane
 
 	if resp.Summary.Sums == nil {
 		errMsg := "[lolalytics] Champion data not ready, " + champion.Name + " " + curLane
-		if aram {
+		if opts.Aram {
 			errMsg = "[lolalytics-ARAM] Champion data not ready, " + champion.Name + " " + curLane
 		}
 		fmt.Println(errMsg)
@@ -167,14 +245,46 @@ func makeBuild(champion common.ChampionItem, query string, sourceVersion string,
 		OfficialVersion: officialVer,
 	}
 
+	header := resp.Header
+	defaultBuild.Meta = &common.ChampionMeta{
+		Tier:      header.Tier,
+		Rank:      header.Rank,
+		RankTotal: int(header.RankTotal),
+		WinRate:   header.Wr,
+		PickRate:  header.Pr,
+		BanRate:   header.Br,
+		Damage: &common.DamageProfile{
+			Physical: header.Damage.Physical,
+			Magic:    header.Damage.Magic,
+			True:     header.Damage.True,
+		},
+	}
+
+	summary := resp.Summary
+	defaultBuild.Skills = common.SkillLetters(summary.Skillpriority.Pick.ID)
+	defaultBuild.SkillOrders = []common.SkillOrder{
+		makeSkillOrder("Highest Win", summary.Skillorder.Win.ID, summary.Skillorder.Win.N, summary.Skillorder.Win.Wr),
+		makeSkillOrder("Most Common", summary.Skillorder.Pick.ID, summary.Skillorder.Pick.N, summary.Skillorder.Pick.Wr),
+	}
+	// most common pair first, then highest win pair if it differs
+	defaultBuild.Spells = common.SpellNames(summary.Sum.Pick.ID, spellLookUp)
+	if summary.Sum.Win.ID != summary.Sum.Pick.ID {
+		defaultBuild.Spells = append(defaultBuild.Spells, common.SpellNames(summary.Sum.Win.ID, spellLookUp)...)
+	}
+
+	defaultBuild.Counters = makeMatchups(resp, championAliasList)
+	defaultBuild.Trends = makeTrends(resp)
+
+	scope := scopeLabel(opts)
 	buildTitlePrefix := "[lolalytics]"
-	buildTitleSuffix := "@" + curLane + ", " + sourceVersion + " (G+)"
+	buildTitleSuffix := "@" + curLane + ", " + sourceVersion + " " + scope
 	associatedMaps := []int{11, 12}
-	if aram {
+	if opts.Aram {
 		buildTitlePrefix = "[lolalytics-ARAM]"
-		buildTitleSuffix = ", " + sourceVersion + " (G+)"
+		buildTitleSuffix = ", " + sourceVersion + " " + scope
 		associatedMaps = []int{12}
 	}
+	extraBlocks := makeExtraBlocks(resp)
 	highestWinBuild := common.ItemBuild{
 		Title:               buildTitlePrefix + " Highest Win" + buildTitleSuffix,
 		AssociatedMaps:      associatedMaps,
@@ -185,7 +295,7 @@ func makeBuild(champion common.ChampionItem, query string, sourceVersion string,
 		Sortrank:            1,
 		StartedFrom:         "blank",
 		Type:                "custom",
-		Blocks:              makeBuildBlocksFromSet(resp.Summary.Items.Win),
+		Blocks:              append(makeBuildBlocksFromSet(resp.Summary.Items.Win), extraBlocks...),
 	}
 	defaultBuild.ItemBuilds = append(defaultBuild.ItemBuilds, highestWinBuild)
 	mostCommonBuild := common.ItemBuild{
@@ -198,21 +308,21 @@ func makeBuild(champion common.ChampionItem, query string, sourceVersion string,
 		Sortrank:            1,
 		StartedFrom:         "blank",
 		Type:                "custom",
-		Blocks:              makeBuildBlocksFromSet(resp.Summary.Items.Pick),
+		Blocks:              append(makeBuildBlocksFromSet(resp.Summary.Items.Pick), extraBlocks...),
 	}
 	defaultBuild.ItemBuilds = append(defaultBuild.ItemBuilds, mostCommonBuild)
 
 	runeTitlePrefix := "[lolalytics]"
-	runeTitleSuffix := "@" + curLane + ", " + sourceVersion + " (G+)"
-	if aram {
+	runeTitleSuffix := "@" + curLane + ", " + sourceVersion + " " + scope
+	if opts.Aram {
 		runeTitlePrefix = "[lolalytics-ARAM]"
-		runeTitleSuffix = ", " + sourceVersion + " (G+)"
+		runeTitleSuffix = ", " + sourceVersion + " " + scope
 	}
 	highestWinRune := common.RuneItem{
 		Alias:           champion.Id,
 		Name:            runeTitlePrefix + " Highest Win" + runeTitleSuffix,
 		Position:        curLane,
-		WinRate:         fmt.Sprintf("%v%%", resp.Summary.Runes.Win.Wr),
+		WinRate:         resp.Summary.Runes.Win.Wr,
 		SelectedPerkIds: concatRuneIds(resp.Summary.Runes.Win.Set.Pri, resp.Summary.Runes.Win.Set.Sec, resp.Summary.Runes.Win.Set.Mod),
 		PrimaryStyleId:  common.GetPrimaryIdForRune(resp.Summary.Runes.Win.Set.Pri[0], runeLookUp),
 		SubStyleId:      common.GetPrimaryIdForRune(resp.Summary.Runes.Win.Set.Sec[0], runeLookUp),
@@ -223,7 +333,7 @@ func makeBuild(champion common.ChampionItem, query string, sourceVersion string,
 		Alias:           champion.Id,
 		Name:            runeTitlePrefix + " Most Common" + runeTitleSuffix,
 		Position:        curLane,
-		WinRate:         fmt.Sprintf("%v%%", resp.Summary.Runes.Pick.Wr),
+		WinRate:         resp.Summary.Runes.Pick.Wr,
 		SelectedPerkIds: concatRuneIds(resp.Summary.Runes.Pick.Set.Pri, resp.Summary.Runes.Pick.Set.Sec, resp.Summary.Runes.Pick.Set.Mod),
 		PrimaryStyleId:  common.GetPrimaryIdForRune(resp.Summary.Runes.Pick.Set.Pri[0], runeLookUp),
 		SubStyleId:      common.GetPrimaryIdForRune(resp.Summary.Runes.Pick.Set.Sec[0], runeLookUp),
@@ -231,9 +341,29 @@ func makeBuild(champion common.ChampionItem, query string, sourceVersion string,
 	}
 	defaultBuild.Runes = append(defaultBuild.Runes, mostCommonRune)
 
+	if len(resp.Runes.Stats) > 0 {
+		optimized := runeTree.Optimize(runeScorer(resp))
+		for i, r := range optimized {
+			if i >= optimizedRunePages {
+				break
+			}
+
+			defaultBuild.Runes = append(defaultBuild.Runes, common.RuneItem{
+				Alias:           champion.Id,
+				Name:            runeTitlePrefix + " Optimized #" + strconv.Itoa(i+1) + runeTitleSuffix,
+				Position:        curLane,
+				PrimaryStyleId:  r.Style,
+				SubStyleId:      r.SubStyle,
+				SelectedPerkIds: r.PerkIds(),
+				Score:           r.Score + r.SubScore,
+			})
+		}
+	}
+	defaultBuild.RuneStats = makeRuneStats(resp)
+
 	builds = append(builds, defaultBuild)
 
-	if fetchMore && !aram {
+	if fetchMore && !opts.Aram {
 		var restLanes []string
 		for _, lane := range common.GetKeys(resp.Nav.Lanes) {
 			if (lane != curLane) && (resp.Nav.Lanes[lane] >= MinimumPickRate) {
@@ -248,9 +378,10 @@ func makeBuild(champion common.ChampionItem, query string, sourceVersion string,
 			for _, l := range restLanes {
 				wg.Add(1)
 
-				go func(champion common.ChampionItem, query string, sourceVersion string, timestamp int64, cnt int, l string) {
-					q := query + "&lane=" + l
-					r, _ := makeBuild(champion, q, sourceVersion, officialVer, timestamp, cnt, false, runeLookUp, aram)
+				go func(champion common.ChampionItem, query Query, sourceVersion string, timestamp int64, cnt int, l string) {
+					q := query
+					q.Lane = l
+					r, _ := makeBuild(c, champion, championAliasList, q, sourceVersion, officialVer, timestamp, cnt, false, runeLookUp, runeTree, spellLookUp, opts)
 					if r != nil {
 						ch <- *r
 					}
@@ -268,43 +399,43 @@ func makeBuild(champion common.ChampionItem, query string, sourceVersion string,
 		}
 	}
 
-	additionalText := ""
-	if aram {
-		additionalText = "(ARAM mode)"
+	additionalText := "(" + opts.Tier + ")"
+	if opts.Aram {
+		additionalText = "(ARAM mode, " + opts.Tier + ")"
 	}
 	fmt.Printf("[lolalytics] No.%d Fetched: %s@%s %s\n", cnt, champion.Name, curLane, additionalText)
 	return &builds, nil
 }
 
-func Import(championAliasList map[string]common.ChampionItem, officialVer string, timestamp int64, runeLookUp common.IRuneLookUp, aram bool, debug bool) string {
+func Import(championAliasList map[string]common.ChampionItem, officialVer string, timestamp int64, runeLookUp common.IRuneLookUp, runeTree *common.RuneTree, spellLookUp common.ISpellLookUp, opts Options) string {
 	start := time.Now()
-	if aram {
-		fmt.Println("🌉 [lolalytics-aram]: Start...")
-	} else {
-		fmt.Println("🌉 [lolalytics]: Start...")
+	err := opts.Validate()
+	pkgName := getPkgName(opts)
+	fmt.Printf("🌉 [%s]: Start...\n", pkgName)
+	if err != nil {
+		return fmt.Sprintf("🔴 [%s] %s", pkgName, err)
 	}
 
-	buildUrl := "https://lolalytics.com/lol/rengar/build/"
-	if aram {
-		buildUrl = "https://lolalytics.com/lol/rengar/aram/build/"
-	}
-	// get initial patch version/ep etc.
-	body, err := common.MakeRequest(buildUrl)
+	c := NewClient()
+	// get initial patch version etc.
+	epQuery, err := c.Discover(opts.Aram)
 	if err != nil {
-		return err.Error()
+		return fmt.Sprintf("🔴 [%s] %s", pkgName, err)
 	}
-
-	html := string(body)
-	sourceVersion := getSourceVersion(html)
-	eps := epReg.FindAllStringSubmatch(html, -1) // "ep=champion&p=d&v=9&patch=11.9&cid=107&lane=default&tier=platinum_plus&queue=420&region=all"
-	epQuery := eps[0][0]
 	//sourceVersion := getPatchVersion(officialVer)
-	queryMaker := makeQuery(epQuery)
+	sourceVersion := epQuery.Patch
+	epQuery.Tier = opts.Tier
+	epQuery.Region = opts.Region
+	if !opts.Aram {
+		epQuery.Queue = queues[opts.Queue]
+	}
 
-	q := queryMaker("103", "middle", "gold_plus", sourceVersion)
-	tierList, err := getTierList(q)
+	q := epQuery
+	q.Cid = "103"
+	q.Lane = "middle"
+	tierList, err := c.TierList(q)
 	if err != nil {
-		return err.Error()
+		return fmt.Sprintf("🔴 [%s] %s", pkgName, err)
 	}
 
 	cIds := make([]string, 0, len(tierList.Cid))
@@ -312,12 +443,23 @@ func Import(championAliasList map[string]common.ChampionItem, officialVer string
 		cIds = append(cIds, key)
 	}
 
+	// lolalytics may list champions before Data Dragon has them, skip those
+	champions, unknown := resolveChampions(cIds, championAliasList)
+	if len(unknown) > 0 {
+		fmt.Printf("🌉 [%s] Skip champion ids unknown to Data Dragon: %v\n", pkgName, unknown)
+	}
+
 	wg := new(sync.WaitGroup)
 	cnt := 0
 	ch := make(chan []common.ChampionDataItem, len(cIds))
 
 	for _, cid := range cIds {
-		if debug && cnt == 7 {
+		champion, ok := champions[cid]
+		if !ok {
+			continue
+		}
+
+		if opts.Debug && cnt == 7 {
 			break
 		}
 
@@ -329,11 +471,12 @@ func Import(championAliasList map[string]common.ChampionItem, officialVer string
 		cnt += 1
 		wg.Add(1)
 
-		champion := getChampionById(cid, championAliasList)
-		query := queryMaker(cid, "default", "gold_plus", sourceVersion)
+		query := epQuery
+		query.Cid = cid
+		query.Lane = "default"
 
 		go func() {
-			builds, err := makeBuild(champion, query, sourceVersion, officialVer, timestamp, cnt, true, runeLookUp, aram)
+			builds, err := makeBuild(c, champion, championAliasList, query, sourceVersion, officialVer, timestamp, cnt, true, runeLookUp, runeTree, spellLookUp, opts)
 			if err == nil {
 				ch <- *builds
 			}
@@ -349,15 +492,19 @@ func Import(championAliasList map[string]common.ChampionItem, officialVer string
 	for i := range ch {
 		data = append(data, i)
 	}
-	pkgName := `lolalytics`
-	if aram {
-		pkgName = `lolalytics-aram`
-	}
-	common.Write2Folder(data, pkgName, timestamp, sourceVersion, officialVer)
+	common.Write2Folder(data, common.PkgInfo{
+		PkgName:         pkgName,
+		Timestamp:       timestamp,
+		SourceVersion:   sourceVersion,
+		OfficialVersion: officialVer,
+		Tier:            opts.Tier,
+		Region:          opts.Region,
+		Queue:           opts.Queue,
+	})
 
 	duration := time.Since(start)
-	if aram {
-		return fmt.Sprintf("🟢 [lolalytics-aram] Finished, took: %s.", duration)
+	if len(unknown) > 0 {
+		return fmt.Sprintf("🟢 [%s] Finished, took: %s. Skipped unknown champion ids: %v.", pkgName, duration, unknown)
 	}
-	return fmt.Sprintf("🟢 [lolalytics] Finished, took: %s.", duration)
+	return fmt.Sprintf("🟢 [%s] Finished, took: %s.", pkgName, duration)
 }
@@ -0,0 +1,42 @@
+package lolalytics
+
+import (
+	"testing"
+)
+
+func TestGetPkgName(t *testing.T) {
+	cases := []struct {
+		opts Options
+		want string
+	}{
+		{opts: Options{Tier: DefaultTier, Region: DefaultRegion, Queue: DefaultQueue}, want: `lolalytics`},
+		{opts: Options{Aram: true, Tier: DefaultTier, Region: DefaultRegion}, want: `lolalytics-aram`},
+		{opts: Options{Tier: `diamond_plus`, Region: DefaultRegion, Queue: DefaultQueue}, want: `lolalytics-diamond_plus`},
+		{opts: Options{Aram: true, Tier: `all`, Region: DefaultRegion}, want: `lolalytics-aram-all`},
+		{opts: Options{Tier: `diamond_plus`, Region: `kr`, Queue: `flex`}, want: `lolalytics-diamond_plus-kr-flex`},
+		{opts: Options{Aram: true, Tier: DefaultTier, Region: `euw`, Queue: `flex`}, want: `lolalytics-aram-euw`},
+	}
+
+	for _, c := range cases {
+		if got := getPkgName(c.opts); got != c.want {
+			t.Errorf("getPkgName(%+v) = %q, want %q", c.opts, got, c.want)
+		}
+	}
+}
+
+func TestScopeLabel(t *testing.T) {
+	cases := []struct {
+		opts Options
+		want string
+	}{
+		{opts: Options{Tier: DefaultTier, Region: DefaultRegion, Queue: DefaultQueue}, want: `(G+)`},
+		{opts: Options{Aram: true, Tier: `all`, Region: `euw`}, want: `(All, EUW)`},
+		{opts: Options{Tier: `diamond_plus`, Region: `kr`, Queue: `flex`}, want: `(D+, KR, Flex)`},
+	}
+
+	for _, c := range cases {
+		if got := scopeLabel(c.opts); got != c.want {
+			t.Errorf("scopeLabel(%+v) = %q, want %q", c.opts, got, c.want)
+		}
+	}
+}
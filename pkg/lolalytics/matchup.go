@@ -0,0 +1,66 @@
+package lolalytics
+
+import (
+	"data-crawler/pkg/common"
+	"sort"
+	"strconv"
+)
+
+// columns of `enemy_*` rows: [cid, games, win rate, ...]
+const (
+	enemyCidCol   = 0
+	enemyGamesCol = 1
+	enemyWrCol    = 2
+)
+
+func makeMatchups(resp *IChampionData, championAliasList map[string]common.ChampionItem) []common.MatchupItem {
+	enemies := map[string][][]float64{
+		"top":     resp.EnemyTop,
+		"jungle":  resp.EnemyJungle,
+		"middle":  resp.EnemyMiddle,
+		"bottom":  resp.EnemyBottom,
+		"support": resp.EnemySupport,
+	}
+
+	counters := make(map[int]string)
+	for _, cid := range resp.Header.Counters.Strong {
+		counters[cid] = "strong"
+	}
+	for _, cid := range resp.Header.Counters.Weak {
+		counters[cid] = "weak"
+	}
+
+	var matchups []common.MatchupItem
+	for lane, rows := range enemies {
+		for _, row := range rows {
+			if len(row) <= enemyWrCol {
+				continue
+			}
+
+			cid := int(row[enemyCidCol])
+			champion, ok := getChampionById(strconv.Itoa(cid), championAliasList)
+			if !ok {
+				continue
+			}
+
+			matchups = append(matchups, common.MatchupItem{
+				Alias:    champion.Id,
+				Name:     champion.Name,
+				Position: lane,
+				Games:    int(row[enemyGamesCol]),
+				WinRate:  row[enemyWrCol],
+				Delta:    row[enemyWrCol] - resp.Header.Wr,
+				Counter:  counters[cid],
+			})
+		}
+	}
+
+	sort.Slice(matchups, func(i, j int) bool {
+		if matchups[i].Position != matchups[j].Position {
+			return matchups[i].Position < matchups[j].Position
+		}
+		return matchups[i].Games > matchups[j].Games
+	})
+
+	return matchups
+}
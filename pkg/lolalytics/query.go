@@ -0,0 +1,49 @@
+package lolalytics
+
+import (
+	"net/url"
+)
+
+const (
+	DefaultRegion = "all"
+	DefaultQueue  = "solo"
+)
+
+var queues = map[string]string{
+	"solo": "420",
+	"flex": "440",
+}
+
+var regions = []string{"all", "kr", "euw", "eune", "na", "br", "lan", "las", "oce", "ru", "tr", "jp"}
+
+type Query struct {
+	Ep     string
+	P      string
+	V      string
+	Patch  string
+	Cid    string
+	Lane   string
+	Tier   string
+	Queue  string
+	Region string
+}
+
+func (q Query) Encode() string {
+	values := url.Values{}
+	set := func(key string, val string) {
+		if len(val) > 0 {
+			values.Set(key, val)
+		}
+	}
+
+	set("ep", q.Ep)
+	set("p", q.P)
+	set("v", q.V)
+	set("patch", q.Patch)
+	set("cid", q.Cid)
+	set("lane", q.Lane)
+	set("tier", q.Tier)
+	set("queue", q.Queue)
+	set("region", q.Region)
+	return values.Encode()
+}
@@ -0,0 +1,28 @@
+package lolalytics
+
+import (
+	"testing"
+)
+
+func TestQueryEncode(t *testing.T) {
+	cases := []struct {
+		query Query
+		want  string
+	}{
+		{query: Query{}, want: ``},
+		{
+			query: Query{Ep: `champion`, P: `d`, V: `9`, Patch: `11.5`, Cid: `103`, Lane: `middle`},
+			want:  `cid=103&ep=champion&lane=middle&p=d&patch=11.5&v=9`,
+		},
+		{
+			query: Query{Ep: `champion`, Tier: `diamond_plus`, Queue: `440`, Region: `kr`},
+			want:  `ep=champion&queue=440&region=kr&tier=diamond_plus`,
+		},
+	}
+
+	for _, c := range cases {
+		if got := c.query.Encode(); got != c.want {
+			t.Errorf("%+v.Encode() = %q, want %q", c.query, got, c.want)
+		}
+	}
+}
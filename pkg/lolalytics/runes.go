@@ -0,0 +1,56 @@
+package lolalytics
+
+import (
+	"data-crawler/pkg/common"
+	"sort"
+	"strconv"
+)
+
+const (
+	optimizedRunePages = 2
+	// games of champion's average win rate mixed into each rune, see `runeScorer`
+	runePriorGames = 100
+)
+
+// columns of `runes.stats` values: [pick rate, win rate, games]
+const (
+	runeStatPrCol    = 0
+	runeStatWrCol    = 1
+	runeStatGamesCol = 2
+)
+
+func makeRuneStats(resp *IChampionData) []common.RuneStat {
+	var stats []common.RuneStat
+	for key, v := range resp.Runes.Stats {
+		id, err := strconv.Atoi(key)
+		if err != nil || len(v) <= runeStatGamesCol {
+			continue
+		}
+
+		stats = append(stats, common.RuneStat{
+			Id:       id,
+			PickRate: v[runeStatPrCol],
+			WinRate:  v[runeStatWrCol],
+			Games:    int(v[runeStatGamesCol]),
+		})
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Id < stats[j].Id
+	})
+
+	return stats
+}
+
+// runeScorer shrinks win rate of each rune towards the champion's average, so rarely picked runes won't stand out.
+func runeScorer(resp *IChampionData) common.RuneScorer {
+	avg := resp.Header.Wr
+	return func(id int) float64 {
+		v := resp.Runes.Stats[strconv.Itoa(id)]
+		if len(v) <= runeStatGamesCol {
+			return 0
+		}
+
+		return common.ShrinkWinRate(v[runeStatWrCol], v[runeStatGamesCol], avg, runePriorGames)
+	}
+}
@@ -0,0 +1,51 @@
+package lolalytics
+
+import (
+	"data-crawler/pkg/common"
+)
+
+func (g IGraphDataItem) brackets() map[string][]float64 {
+	return map[string][]float64{
+		"all":          g.All,
+		"diamond_plus": g.DiamondPlus,
+		"platinum":     g.Platinum,
+		"gold":         g.Gold,
+		"silver":       g.Silver,
+		"bronze":       g.Bronze,
+		"iron":         g.Iron,
+	}
+}
+
+func valueAt(arr []float64, i int) float64 {
+	if i >= len(arr) {
+		return 0
+	}
+	return arr[i]
+}
+
+// makeTrends returns daily stats of current patch, by rank bracket
+func makeTrends(resp *IChampionData) map[string][]common.TrendPoint {
+	graph := resp.Graph
+	wr, pr, br, n := graph.Wr.brackets(), graph.Pr.brackets(), graph.Br.brackets(), graph.N.brackets()
+
+	trends := make(map[string][]common.TrendPoint)
+	for bracket, wrs := range wr {
+		if len(wrs) == 0 {
+			continue
+		}
+
+		var points []common.TrendPoint
+		for i, date := range graph.Dates {
+			points = append(points, common.TrendPoint{
+				Date:     date,
+				WinRate:  valueAt(wrs, i),
+				PickRate: valueAt(pr[bracket], i),
+				BanRate:  valueAt(br[bracket], i),
+				Games:    int(valueAt(n[bracket], i)),
+			})
+		}
+		trends[bracket] = points
+	}
+
+	return trends
+}
@@ -0,0 +1,86 @@
+package murderbridge
+
+import (
+	"data-crawler/pkg/common"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// win rate difference between late & early games to label a champion, in percent
+const durationLabelThreshold = 2
+
+// parseMinute reads the start of a duration bucket, e.g. `15` or `15-20`
+func parseMinute(key string) (int, bool) {
+	end := strings.IndexFunc(key, func(r rune) bool {
+		return r < '0' || r > '9'
+	})
+	if end == 0 {
+		return 0, false
+	}
+	if end > 0 {
+		key = key[:end]
+	}
+
+	minute, err := strconv.Atoi(key)
+	return minute, err == nil
+}
+
+func makeDurationCurve(duration map[string]StatItem, winRate float64) *common.DurationCurve {
+	var total float64
+	var points []common.DurationPoint
+	for k, v := range duration {
+		minute, ok := parseMinute(k)
+		if !ok {
+			continue
+		}
+
+		total += v.Frequency
+		points = append(points, common.DurationPoint{
+			Minute:  minute,
+			WinRate: v.WinRate,
+			Delta:   v.WinRate - winRate,
+			Share:   v.Frequency,
+		})
+	}
+	if len(points) == 0 {
+		return nil
+	}
+
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Minute < points[j].Minute
+	})
+
+	// compare weighted win rate of the shorter half of games with the longer half,
+	// a bucket belongs to the shorter half until cumulative share reaches 0.5
+	var early, earlyWeight, late, lateWeight, cumulative float64
+	for i := range points {
+		if total > 0 {
+			points[i].Share /= total
+		}
+
+		if cumulative < 0.5 {
+			early += points[i].Delta * points[i].Share
+			earlyWeight += points[i].Share
+		} else {
+			late += points[i].Delta * points[i].Share
+			lateWeight += points[i].Share
+		}
+		cumulative += points[i].Share
+	}
+
+	curve := common.DurationCurve{
+		Points: points,
+		Label:  `balanced`,
+	}
+	if earlyWeight > 0 && lateWeight > 0 {
+		diff := late/lateWeight - early/earlyWeight
+		if diff > durationLabelThreshold {
+			curve.Label = `late`
+		} else if diff < -durationLabelThreshold {
+			curve.Label = `early`
+		}
+	}
+
+	return &curve
+}
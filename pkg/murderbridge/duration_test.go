@@ -0,0 +1,96 @@
+package murderbridge
+
+import (
+	"testing"
+)
+
+func TestParseMinute(t *testing.T) {
+	cases := []struct {
+		key    string
+		minute int
+		ok     bool
+	}{
+		{key: `15`, minute: 15, ok: true},
+		{key: `15-20`, minute: 15, ok: true},
+		{key: `-5`, ok: false},
+		{key: `total`, ok: false},
+	}
+
+	for _, c := range cases {
+		minute, ok := parseMinute(c.key)
+		if minute != c.minute || ok != c.ok {
+			t.Errorf("parseMinute(%q) = %d, %v, want %d, %v", c.key, minute, ok, c.minute, c.ok)
+		}
+	}
+}
+
+func TestMakeDurationCurve(t *testing.T) {
+	if got := makeDurationCurve(nil, 50); got != nil {
+		t.Errorf("makeDurationCurve(nil) = %v, want nil", got)
+	}
+
+	cases := []struct {
+		name     string
+		duration map[string]StatItem
+		label    string
+	}{
+		{
+			name: `late`,
+			duration: map[string]StatItem{
+				`10`: {WinRate: 45, Frequency: 30},
+				`20`: {WinRate: 50, Frequency: 40},
+				`30`: {WinRate: 56, Frequency: 30},
+			},
+			label: `late`,
+		},
+		{
+			name: `early`,
+			duration: map[string]StatItem{
+				`10`: {WinRate: 56, Frequency: 30},
+				`20`: {WinRate: 50, Frequency: 40},
+				`30`: {WinRate: 45, Frequency: 30},
+			},
+			label: `early`,
+		},
+		{
+			name: `balanced`,
+			duration: map[string]StatItem{
+				`10`: {WinRate: 50, Frequency: 50},
+				`20`: {WinRate: 51, Frequency: 50},
+			},
+			label: `balanced`,
+		},
+		{
+			// most games end by 10 minutes, the 20 minute bucket belongs to the longer half
+			name: `uneven buckets`,
+			duration: map[string]StatItem{
+				`10`: {WinRate: 49, Frequency: 60},
+				`20`: {WinRate: 42, Frequency: 10},
+				`30`: {WinRate: 53, Frequency: 10},
+				`40`: {WinRate: 53, Frequency: 20},
+			},
+			label: `balanced`,
+		},
+	}
+
+	for _, c := range cases {
+		curve := makeDurationCurve(c.duration, 50)
+		if curve == nil {
+			t.Fatalf("%s: makeDurationCurve() = nil", c.name)
+		}
+		if curve.Label != c.label {
+			t.Errorf("%s: label = %q, want %q", c.name, curve.Label, c.label)
+		}
+
+		var share float64
+		for i, p := range curve.Points {
+			share += p.Share
+			if i > 0 && p.Minute <= curve.Points[i-1].Minute {
+				t.Errorf("%s: points not sorted by minute: %v", c.name, curve.Points)
+			}
+		}
+		if share < 0.999 || share > 1.001 {
+			t.Errorf("%s: shares sum to %f, want 1", c.name, share)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package murderbridge
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+const DefaultGameType = `ARAM`
+
+// associated maps of game types, summoner's rift for the rest
+var gameTypeMaps = map[string][]int{
+	`ARAM`:       {12},
+	`NEXUSBLITZ`: {21},
+}
+
+// gameType keeps the advertised name for data urls, `Name` is upper-cased for everything else
+type gameType struct {
+	Name string
+	Path string
+}
+
+func getAssociatedMaps(gameType string) []int {
+	if maps, ok := gameTypeMaps[gameType]; ok {
+		return maps
+	}
+	return []int{11}
+}
+
+// parseGameTypes accepts a JSON array, or comma separated names
+func parseGameTypes(raw string) []gameType {
+	var gameTypes []string
+	if err := json.Unmarshal([]byte(raw), &gameTypes); err != nil {
+		gameTypes = strings.Split(raw, `,`)
+	}
+
+	var ret []gameType
+	for _, t := range gameTypes {
+		t = strings.TrimSpace(t)
+		if len(t) > 0 {
+			ret = append(ret, gameType{Name: strings.ToUpper(t), Path: t})
+		}
+	}
+
+	if len(ret) == 0 {
+		return []gameType{{Name: DefaultGameType, Path: DefaultGameType}}
+	}
+	return ret
+}
+
+// scorerLabel is empty for the default scorer
+func scorerLabel(scorer string) string {
+	if scorer == DefaultScorer {
+		return ``
+	}
+	return scorer
+}
+
+func getPkgName(gameType string, version string, scorer string) string {
+	pkgName := MurderBridge
+	if gameType != DefaultGameType {
+		pkgName += `-` + strings.ToLower(gameType)
+	}
+	if len(version) > 0 {
+		pkgName += `-` + version
+	}
+	if label := scorerLabel(scorer); len(label) > 0 {
+		pkgName += `-` + label
+	}
+	return pkgName
+}
+
+// e.g. `[MB] `, `[MB-URF] ` or `[MB-URF-wilson] `
+func getTitlePrefix(gameType string, scorer string) string {
+	labels := []string{`MB`}
+	if gameType != DefaultGameType {
+		labels = append(labels, gameType)
+	}
+	if label := scorerLabel(scorer); len(label) > 0 {
+		labels = append(labels, label)
+	}
+	return `[` + strings.Join(labels, `-`) + `] `
+}
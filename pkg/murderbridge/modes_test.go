@@ -0,0 +1,80 @@
+package murderbridge
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseGameTypes(t *testing.T) {
+	cases := []struct {
+		raw  string
+		want []gameType
+	}{
+		{raw: `["ARAM","URF"]`, want: []gameType{{Name: `ARAM`, Path: `ARAM`}, {Name: `URF`, Path: `URF`}}},
+		{raw: `ARAM, urf`, want: []gameType{{Name: `ARAM`, Path: `ARAM`}, {Name: `URF`, Path: `urf`}}},
+		{raw: `["aram"]`, want: []gameType{{Name: `ARAM`, Path: `aram`}}},
+		{raw: ` , `, want: []gameType{{Name: DefaultGameType, Path: DefaultGameType}}},
+		{raw: ``, want: []gameType{{Name: DefaultGameType, Path: DefaultGameType}}},
+	}
+
+	for _, c := range cases {
+		if got := parseGameTypes(c.raw); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("parseGameTypes(%q) = %v, want %v", c.raw, got, c.want)
+		}
+	}
+}
+
+func TestGetPkgName(t *testing.T) {
+	cases := []struct {
+		gameType string
+		version  string
+		scorer   string
+		want     string
+	}{
+		{gameType: `ARAM`, scorer: DefaultScorer, want: `murderbridge`},
+		{gameType: `URF`, scorer: DefaultScorer, want: `murderbridge-urf`},
+		{gameType: `ARAM`, version: `11.5`, scorer: DefaultScorer, want: `murderbridge-11.5`},
+		{gameType: `URF`, version: `11.5`, scorer: `wilson`, want: `murderbridge-urf-11.5-wilson`},
+	}
+
+	for _, c := range cases {
+		if got := getPkgName(c.gameType, c.version, c.scorer); got != c.want {
+			t.Errorf("getPkgName(%q, %q, %q) = %q, want %q", c.gameType, c.version, c.scorer, got, c.want)
+		}
+	}
+}
+
+func TestGetTitlePrefix(t *testing.T) {
+	cases := []struct {
+		gameType string
+		scorer   string
+		want     string
+	}{
+		{gameType: `ARAM`, scorer: DefaultScorer, want: `[MB] `},
+		{gameType: `URF`, scorer: DefaultScorer, want: `[MB-URF] `},
+		{gameType: `ARAM`, scorer: `bayes`, want: `[MB-bayes] `},
+	}
+
+	for _, c := range cases {
+		if got := getTitlePrefix(c.gameType, c.scorer); got != c.want {
+			t.Errorf("getTitlePrefix(%q, %q) = %q, want %q", c.gameType, c.scorer, got, c.want)
+		}
+	}
+}
+
+func TestGetAssociatedMaps(t *testing.T) {
+	cases := []struct {
+		gameType string
+		want     []int
+	}{
+		{gameType: `ARAM`, want: []int{12}},
+		{gameType: `NEXUSBLITZ`, want: []int{21}},
+		{gameType: `URF`, want: []int{11}},
+	}
+
+	for _, c := range cases {
+		if got := getAssociatedMaps(c.gameType); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("getAssociatedMaps(%q) = %v, want %v", c.gameType, got, c.want)
+		}
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"math"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -49,30 +51,11 @@ type ScoreItem struct {
 	Score   float64 `json:"score"`
 }
 
-type SubPerkItem struct {
-	Rune0 common.RespRuneItem `json:"perk0"`
-	Rune1 common.RespRuneItem `json:"perk1"`
-	Score float64             `json:"score"`
-	Style int                 `json:"style"`
-}
-
-type OptimalSubPerk struct {
-	SubRunes []int   `json:"subRunes"`
-	SubStyle int     `json:"subStyle"`
-	SubScore float64 `json:"subScore"`
-}
-
-type PerkStyleItem struct {
-	Style     int     `json:"style"`
-	Score     float64 `json:"mainScore"`
-	Runes     []int   `json:"runes"`
-	SubStyle  int     `json:"subStyle"`
-	SubScore  float64 `json:"subScore"`
-	SubRunes  []int   `json:"subRunes"`
-	Fragments []int   `json:"fragments"`
-}
+// block titles of item slots, following `Items.Order`
+var slotNames = []string{`First Item`, `Second Item`, `Third Item`}
 
 const (
+	itemsPerSlot    = 4
 	MurderBridge    = `murderbridge`
 	MurderBridgeUrl = `https://d23wati96d2ixg.cloudfront.net`
 	e               = 2.71828
@@ -81,22 +64,42 @@ const (
 	spread          = 100 - generalRatio
 )
 
-var (
-	items      *map[string]common.BuildItem
-	runeLoopUp map[int]*common.RespRuneItem
-	allRunes   *[]common.RuneSlot
-)
+// Importer holds item & rune data of one import, create one for each version to run them concurrently.
+type Importer struct {
+	// rankings scored by the default scorer as their data lacks games, see `pickScorer`
+	fallbacks int64
+
+	// Version of murderbridge data, latest if empty
+	Version string
 
-func getLatestVersion() (string, error) {
+	runeTree    *common.RuneTree
+	spellLookUp common.ISpellLookUp
+	scorer      Scorer
+	items       map[string]common.BuildItem
+}
+
+func NewImporter(runeTree *common.RuneTree, spellLookUp common.ISpellLookUp, scorer Scorer) *Importer {
+	if scorer == nil {
+		scorer = LogisticScorer{}
+	}
+
+	return &Importer{
+		runeTree:    runeTree,
+		spellLookUp: spellLookUp,
+		scorer:      scorer,
+	}
+}
+
+func getGeneral() (*VersionResp, error) {
 	url := MurderBridgeUrl + `/save/general.json`
 	body, err := common.MakeRequest(url)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	var verResp VersionResp
 	_ = json.Unmarshal(body, &verResp)
-	return verResp.UpToDateVersion, nil
+	return &verResp, nil
 }
 
 func scorer(winRate float64, frequency float64) float64 {
@@ -115,11 +118,20 @@ func scorer(winRate float64, frequency float64) float64 {
 	return winRate * score
 }
 
-func getItemList(data map[string]StatItem, limit int) []ScoreItem {
+func (m *Importer) scorerFor(data map[string]StatItem) Scorer {
+	scorer, fellBack := pickScorer(m.scorer, data)
+	if fellBack {
+		atomic.AddInt64(&m.fallbacks, 1)
+	}
+	return scorer
+}
+
+func (m *Importer) getItemList(data map[string]StatItem, limit int) []ScoreItem {
+	scorer := m.scorerFor(data)
 	keyScoreMap := []ScoreItem{}
 	for k, v := range data {
 		item := ScoreItem{
-			Score:   scorer(v.WinRate, v.Frequency),
+			Score:   scorer.Score(v),
 			RawItem: k,
 		}
 		keyScoreMap = append(keyScoreMap, item)
@@ -139,32 +151,80 @@ func getItemList(data map[string]StatItem, limit int) []ScoreItem {
 	return keyScoreMap[0:limit]
 }
 
-func makeBlocks(data ChampionDataResp) []common.ItemBuildBlockItem {
-	starting := getItemList(data.Items.Starting, 3)
-	builds := getItemList(data.Items.Build, 13)
+func (m *Importer) makeOrderBlocks(order []map[string]StatItem) []common.ItemBuildBlockItem {
+	var blocks []common.ItemBuildBlockItem
+	for i, slot := range order {
+		if i >= len(slotNames) {
+			break
+		}
 
-	var startingItems []string
-	var buildItems []string
-	var bootIds []string
+		var ids []string
+		for _, v := range m.getItemList(slot, len(slot)) {
+			// boots have their own block
+			if common.IsBoot(v.RawItem, m.items) {
+				continue
+			}
 
-	for _, v := range starting {
+			ids = append(ids, v.RawItem)
+			if len(ids) == itemsPerSlot {
+				break
+			}
+		}
+
+		if len(ids) > 0 {
+			blocks = append(blocks, common.MakeBuildBlock(ids, slotNames[i]))
+		}
+	}
+
+	return blocks
+}
+
+// makeStartingBlocks keeps each starting set as an alternative, item sets are [[id, quantity], ...]
+func (m *Importer) makeStartingBlocks(starting map[string]StatItem) []common.ItemBuildBlockItem {
+	var blocks []common.ItemBuildBlockItem
+	for idx, v := range m.getItemList(starting, 3) {
 		var itemSet [][2]int
-		_ = json.Unmarshal([]byte(v.RawItem), &itemSet)
+		if err := json.Unmarshal([]byte(v.RawItem), &itemSet); err != nil {
+			continue
+		}
+
+		stat := starting[v.RawItem]
+		block := common.NewStatBlock(`Starter Items #`+strconv.Itoa(idx+1), int(stat.games()), stat.Frequency, stat.WinRate)
 		for _, j := range itemSet {
-			startingItems = common.NoRepeatPush(strconv.Itoa(j[0]), startingItems)
+			count := j[1]
+			if count < 1 {
+				count = 1
+			}
+			block.Items = append(block.Items, common.BlockItem{
+				Id:    strconv.Itoa(j[0]),
+				Count: count,
+			})
 		}
+		blocks = append(blocks, block)
 	}
+
+	var wardItems []string
 	// wards
 	for _, id := range common.WardItems {
-		startingItems = common.NoRepeatPush(id, startingItems)
+		wardItems = common.NoRepeatPush(id, wardItems)
 	}
 	// trinkets
 	for _, id := range common.TrinketItems {
-		startingItems = common.NoRepeatPush(id, startingItems)
+		wardItems = common.NoRepeatPush(id, wardItems)
 	}
+	blocks = append(blocks, common.MakeBuildBlock(wardItems, `Wards & Trinkets`))
+
+	return blocks
+}
+
+func (m *Importer) makeBlocks(data ChampionDataResp) []common.ItemBuildBlockItem {
+	builds := m.getItemList(data.Items.Build, 13)
+
+	var buildItems []string
+	var bootIds []string
 
 	for _, v := range builds {
-		if common.IsBoot(v.RawItem, *items) {
+		if common.IsBoot(v.RawItem, m.items) {
 			bootIds = append(bootIds, v.RawItem)
 			continue
 		}
@@ -172,159 +232,58 @@ func makeBlocks(data ChampionDataResp) []common.ItemBuildBlockItem {
 		buildItems = common.NoRepeatPush(v.RawItem, buildItems)
 	}
 
-	startingBlocks := common.MakeBuildBlock(startingItems, `Starter Items`)
-	buildBlocks := common.MakeBuildBlock(buildItems, `Recommended Builds`)
+	startingBlocks := m.makeStartingBlocks(data.Items.Starting)
 	bootBlocks := common.MakeBuildBlock(bootIds, `Boots`)
 	consumableItems := common.MakeBuildBlock(common.ConsumableItems, `Consumable Items`)
 
-	items := []common.ItemBuildBlockItem{
-		startingBlocks,
-		buildBlocks,
-		bootBlocks,
-		consumableItems,
+	// fallback to unordered builds if there's no item order data
+	buildBlocks := m.makeOrderBlocks(data.Items.Order)
+	if len(buildBlocks) == 0 {
+		buildBlocks = append(buildBlocks, common.MakeBuildBlock(buildItems, `Recommended Builds`))
 	}
+
+	items := startingBlocks
+	items = append(items, buildBlocks...)
+	items = append(items, bootBlocks)
+	items = append(items, m.makeSituationalBlocks(data.Items.Counter)...)
+	items = append(items, consumableItems)
 	return items
 }
 
-func generateOptimalSubPerks(runes map[string]StatItem) []SubPerkItem {
-	var optimalSubPerks []SubPerkItem
-
-	for _, i := range *allRunes {
-		var targetRunes []*common.RespRuneItem
-		for _, r := range runeLoopUp {
-			if r.Style == i.Id && r.Slot != 0 {
-				targetRunes = append(targetRunes, r)
-			}
-		}
-
-		var bestScore float64
-		var bestRunes SubPerkItem
-
-		for _, r1 := range targetRunes {
-			for _, r2 := range targetRunes {
-				if r1.Slot != r2.Slot {
-					score := scorer(runes[strconv.Itoa(r1.Id)].WinRate, runes[strconv.Itoa(r1.Id)].Frequency) + scorer(runes[strconv.Itoa(r2.Id)].WinRate, runes[strconv.Itoa(r2.Id)].Frequency)
-
-					if score > bestScore {
-						bestScore = score
-						bestRunes = SubPerkItem{
-							Rune0: *r1,
-							Rune1: *r2,
-							Score: score,
-							Style: i.Id,
-						}
-					}
-				}
-			}
-		}
-
-		optimalSubPerks = append(optimalSubPerks, bestRunes)
+func (m *Importer) makeSkills(skills map[string]StatItem) ([]string, []common.SkillOrder) {
+	best := m.getItemList(skills, 1)
+	if len(best) == 0 {
+		return nil, nil
 	}
 
-	sort.Slice(optimalSubPerks, func(i, j int) bool {
-		return optimalSubPerks[i].Score > optimalSubPerks[j].Score
-	})
+	letters := common.SkillLetters(best[0].RawItem)
+	// priority only
+	if len(letters) <= 3 {
+		return letters, nil
+	}
 
-	return optimalSubPerks
+	stat := skills[best[0].RawItem]
+	order := common.SkillOrder{
+		Type:      `Best Scored`,
+		Order:     letters,
+		PickCount: int(stat.games()),
+		PickRate:  stat.Frequency,
+		WinRate:   stat.WinRate,
+	}
+	return common.SkillPriority(letters), []common.SkillOrder{order}
 }
 
-func generateOptimalPerks(runes map[string]StatItem) []PerkStyleItem {
-	var bestScore float64
-	var result []PerkStyleItem
-	scoreMap := make(map[int]float64)
-
-	var fragments []int
-	for _, ids := range common.Fragments {
-		sort.Slice(ids, func(i, j int) bool {
-			iid := strconv.Itoa(ids[i])
-			jid := strconv.Itoa(ids[j])
-			iScore := scorer(runes[iid].WinRate, runes[iid].Frequency)
-			jScore := scorer(runes[jid].WinRate, runes[jid].Frequency)
-
-			scoreMap[ids[i]] = iScore
-			scoreMap[ids[j]] = jScore
-
-			return iScore > jScore
-		})
-		fragments = append(fragments, ids[0])
-	}
-
-	for _, primaryRuneSlot := range *allRunes {
-		var totalScore float64
-		var runeSet []int
-
-		for sIdx, slot := range primaryRuneSlot.Slots {
-			sort.Slice(slot.Runes, func(i, j int) bool {
-				aId := strconv.Itoa(slot.Runes[i].Id)
-				bId := strconv.Itoa(slot.Runes[j].Id)
-				a := runes[aId]
-				b := runes[bId]
-				aScore := scorer(a.WinRate, a.Frequency)
-				bScore := scorer(b.WinRate, b.Frequency)
-				scoreMap[slot.Runes[i].Id] = aScore
-				scoreMap[slot.Runes[j].Id] = bScore
-
-				return aScore > bScore
-			})
-			runeSet = append(runeSet, slot.Runes[0].Id)
-
-			rId := slot.Runes[0].Id
-			if sIdx == 0 {
-				totalScore += 3 * scoreMap[rId]
-			} else {
-				totalScore += scoreMap[rId]
-			}
-		}
-
-		if totalScore > bestScore {
-			bestScore = totalScore
-		}
-
-		primaryPerk := PerkStyleItem{
-			Style: primaryRuneSlot.Id,
-			Score: totalScore,
-			Runes: runeSet,
-		}
-
-		subPerks := generateOptimalSubPerks(runes)
-
-		var bestSubPerks []OptimalSubPerk
-		for _, s := range subPerks {
-			if s.Style == primaryRuneSlot.Id {
-				continue
-			}
-
-			if len(bestSubPerks) < 2 {
-				bestSubPerks = append(bestSubPerks, OptimalSubPerk{
-					SubStyle: s.Style,
-					SubScore: s.Score,
-					SubRunes: []int{s.Rune0.Id, s.Rune1.Id},
-				})
-			}
-		}
-
-		for _, s := range bestSubPerks {
-			result = append(result, PerkStyleItem{
-				Style:     primaryPerk.Style,
-				Score:     primaryPerk.Score,
-				Runes:     primaryPerk.Runes,
-				SubStyle:  s.SubStyle,
-				SubScore:  s.SubScore,
-				SubRunes:  s.SubRunes,
-				Fragments: fragments,
-			})
-		}
+func (m *Importer) makeSpells(summoners map[string]StatItem) []string {
+	best := m.getItemList(summoners, 1)
+	if len(best) == 0 {
+		return nil
 	}
 
-	sort.Slice(result, func(i, j int) bool {
-		return result[i].Score > result[j].Score
-	})
-
-	return result
+	return common.SpellNames(best[0].RawItem, m.spellLookUp)
 }
 
-func genChampionData(champion common.ChampionItem, version string, timestamp int64) (*common.ChampionDataItem, error) {
-	url := MurderBridgeUrl + `/save/` + version + `/ARAM/` + champion.Id + `.json`
+func (m *Importer) genChampionData(champion common.ChampionItem, version string, gt gameType, timestamp int64) (*common.ChampionDataItem, error) {
+	url := MurderBridgeUrl + `/save/` + version + `/` + gt.Path + `/` + champion.Id + `.json`
 	body, err := common.MakeRequest(url)
 	if err != nil {
 		return nil, err
@@ -340,10 +299,16 @@ func genChampionData(champion common.ChampionItem, version string, timestamp int
 	var data ChampionDataResp
 	_ = json.Unmarshal(body, &data)
 	key, _ := strconv.Atoi(champion.Key)
+	result.Skills, result.SkillOrders = m.makeSkills(data.Skills)
+	result.Spells = m.makeSpells(data.Summoners)
+	result.Meta = makeSummary(data)
+	result.Duration = makeDurationCurve(data.Duration, data.WinRate)
+
+	titlePrefix := getTitlePrefix(gt.Name, m.scorer.Name())
 
 	build := common.ItemBuild{
-		Title:               `[MB] ` + champion.Id + ` ` + version,
-		AssociatedMaps:      []int{12},
+		Title:               titlePrefix + champion.Id + ` ` + version,
+		AssociatedMaps:      getAssociatedMaps(gt.Name),
 		AssociatedChampions: []int{key},
 		Map:                 "any",
 		Mode:                "any",
@@ -351,44 +316,66 @@ func genChampionData(champion common.ChampionItem, version string, timestamp int
 		Sortrank:            1,
 		StartedFrom:         "blank",
 		Type:                "custom",
-		Blocks:              makeBlocks(data),
+		Blocks:              m.makeBlocks(data),
 	}
 	result.ItemBuilds = append(result.ItemBuilds, build)
 
-	optimalRunes := generateOptimalPerks(data.Runes)
+	runeScorer := m.scorerFor(data.Runes)
+	optimalRunes := m.runeTree.Optimize(func(id int) float64 {
+		return runeScorer.Score(data.Runes[strconv.Itoa(id)])
+	})
 	for _, r := range optimalRunes {
 		item := common.RuneItem{
-			Alias:          champion.Id,
-			Name:           `[MB] ` + champion.Name,
-			Position:       ``,
-			PrimaryStyleId: r.Style,
-			SubStyleId:     r.SubStyle,
-			Score:          r.Score + r.SubScore,
-		}
-		for _, i := range r.Runes {
-			item.SelectedPerkIds = append(item.SelectedPerkIds, i)
-		}
-		for _, i := range r.SubRunes {
-			item.SelectedPerkIds = append(item.SelectedPerkIds, i)
-		}
-		for _, i := range r.Fragments {
-			item.SelectedPerkIds = append(item.SelectedPerkIds, i)
+			Alias:           champion.Id,
+			Name:            titlePrefix + champion.Name,
+			Position:        ``,
+			PrimaryStyleId:  r.Style,
+			SubStyleId:      r.SubStyle,
+			Score:           r.Score + r.SubScore,
+			SelectedPerkIds: r.PerkIds(),
 		}
 		result.Runes = append(result.Runes, item)
 	}
 
-	fmt.Printf("🤪 [MB] %s: Fetched data. \n", result.Alias)
+	fmt.Printf("🤪 [MB] %s %s: Fetched data. \n", result.Alias, gt.Name)
 	return &result, nil
 }
 
-func Import(championAliasList map[string]common.ChampionItem, timestamp int64, rLookUp common.IRuneLookUp, runes common.IAllRunes, debug bool) string {
+func (m *Importer) Import(championAliasList map[string]common.ChampionItem, timestamp int64, debug bool) string {
 	start := time.Now()
 	fmt.Println("🌉 [MB]: Start...")
 
-	ver, _ := getLatestVersion()
-	items, _ = common.GetItemList(ver)
-	runeLoopUp, allRunes = rLookUp, runes
+	general, err := getGeneral()
+	if err != nil {
+		return fmt.Sprintf("🔴 [MB] Fetch general info failed, %s", err)
+	}
+
+	ver := m.Version
+	if len(ver) == 0 {
+		ver = general.UpToDateVersion
+	}
+
+	itemList, err := common.GetItemList(ver)
+	if err != nil {
+		return fmt.Sprintf("🔴 [MB] Fetch item list failed, %s", err)
+	}
+	m.items = *itemList
+
+	var results []string
+	for _, gt := range parseGameTypes(general.GameTypes) {
+		pkgName := getPkgName(gt.Name, m.Version, m.scorer.Name())
+		results = append(results, m.importGameType(championAliasList, ver, gt, pkgName, timestamp, debug))
+	}
+
+	duration := time.Since(start)
+	if n := atomic.LoadInt64(&m.fallbacks); n > 0 {
+		return fmt.Sprintf("🟢 [MB] Finished %s. Took %s. %d rankings lack games, scored by %s scorer instead of %s.", strings.Join(results, ", "), duration, n, DefaultScorer, m.scorer.Name())
+	}
+	return fmt.Sprintf("🟢 [MB] Finished %s. Took %s.", strings.Join(results, ", "), duration)
+}
 
+// importGameType writes a package for the game type, and describes the result
+func (m *Importer) importGameType(championAliasList map[string]common.ChampionItem, ver string, gt gameType, pkgName string, timestamp int64, debug bool) string {
 	wg := new(sync.WaitGroup)
 	cnt := 0
 	ch := make(chan common.ChampionDataItem, len(championAliasList))
@@ -404,7 +391,7 @@ func Import(championAliasList map[string]common.ChampionItem, timestamp int64, r
 		cnt += 1
 		wg.Add(1)
 		go func(_champion common.ChampionItem, _ver string, _cnt int, _timestamp int64) {
-			d, err := genChampionData(_champion, _ver, timestamp)
+			d, err := m.genChampionData(_champion, _ver, gt, timestamp)
 			if d != nil {
 				ch <- *d
 			} else {
@@ -421,8 +408,18 @@ func Import(championAliasList map[string]common.ChampionItem, timestamp int64, r
 		content := []common.ChampionDataItem{i}
 		data = append(data, content)
 	}
-	common.Write2Folder(data, MurderBridge, timestamp, ver, ver)
 
-	duration := time.Since(start)
-	return fmt.Sprintf("🟢 [MB] Finished. Took %s.", duration)
+	// advertised modes may have no champion data, don't publish an empty package
+	if len(data) == 0 {
+		return fmt.Sprintf("%s: skipped, no champion data", pkgName)
+	}
+
+	common.Write2Folder(data, common.PkgInfo{
+		PkgName:         pkgName,
+		Timestamp:       timestamp,
+		SourceVersion:   ver,
+		OfficialVersion: ver,
+		Scorer:          scorerLabel(m.scorer.Name()),
+	})
+	return fmt.Sprintf("%s: %d champions", pkgName, len(data))
 }
@@ -0,0 +1,159 @@
+package murderbridge
+
+import (
+	"data-crawler/pkg/common"
+	"reflect"
+	"testing"
+)
+
+func TestImporterMakeSkills(t *testing.T) {
+	t.Parallel()
+
+	m := NewImporter(nil, nil, nil)
+
+	cases := []struct {
+		name     string
+		skills   map[string]StatItem
+		priority []string
+		orders   int
+	}{
+		{name: `empty`},
+		{
+			name:     `priority only`,
+			skills:   map[string]StatItem{`QEW`: {WinRate: 52, Frequency: 30}},
+			priority: []string{`Q`, `E`, `W`},
+		},
+		{
+			name:     `full order`,
+			skills:   map[string]StatItem{`QWEQQRQEQEREEWWRWW`: {WinRate: 52, Ratio: []int{52, 100}, Frequency: 30}},
+			priority: []string{`Q`, `E`, `W`},
+			orders:   1,
+		},
+	}
+
+	for _, c := range cases {
+		priority, orders := m.makeSkills(c.skills)
+		if !reflect.DeepEqual(priority, c.priority) || len(orders) != c.orders {
+			t.Errorf("%s: makeSkills() = %v, %d orders, want %v, %d orders", c.name, priority, len(orders), c.priority, c.orders)
+		}
+	}
+}
+
+func TestImporterMakeSpells(t *testing.T) {
+	t.Parallel()
+
+	m := NewImporter(nil, common.ISpellLookUp{`4`: `flash`, `14`: `dot`, `32`: `snowball`}, nil)
+
+	spells := m.makeSpells(map[string]StatItem{
+		`[4,32]`: {WinRate: 55, Frequency: 60},
+		`[4,14]`: {WinRate: 50, Frequency: 10},
+	})
+	if want := []string{`flash`, `snowball`}; !reflect.DeepEqual(spells, want) {
+		t.Errorf("makeSpells() = %v, want %v", spells, want)
+	}
+}
+
+func TestImporterMakeStartingBlocks(t *testing.T) {
+	t.Parallel()
+
+	m := NewImporter(nil, nil, nil)
+
+	blocks := m.makeStartingBlocks(map[string]StatItem{
+		`[[1055,1],[2003,1]]`: {WinRate: 52, Ratio: []int{520, 1000}, Frequency: 60},
+		`[[1056,1],[2003,2]]`: {WinRate: 51, Ratio: []int{102, 200}, Frequency: 20},
+		`not json`:            {WinRate: 60, Frequency: 10},
+	})
+
+	// two starting sets & wards
+	if len(blocks) != 3 {
+		t.Fatalf("makeStartingBlocks() returned %d blocks, want 3", len(blocks))
+	}
+
+	want := []common.BlockItem{{Id: `1056`, Count: 1}, {Id: `2003`, Count: 2}}
+	if !reflect.DeepEqual(blocks[1].Items, want) {
+		t.Errorf("second starting block items = %v, want %v", blocks[1].Items, want)
+	}
+	if blocks[0].PickCount != 1000 || blocks[0].WinRate != 52 {
+		t.Errorf("first starting block stats = %d games, %f win rate", blocks[0].PickCount, blocks[0].WinRate)
+	}
+}
+
+func blockIds(blocks []common.ItemBuildBlockItem, title string) []string {
+	var ids []string
+	for _, b := range blocks {
+		if b.Type != title {
+			continue
+		}
+		for _, item := range b.Items {
+			ids = append(ids, item.Id)
+		}
+	}
+	return ids
+}
+
+// importers of different versions share nothing, run with `go test -race`
+func TestImportersConcurrently(t *testing.T) {
+	var data ChampionDataResp
+	data.Items.Build = map[string]StatItem{
+		`3006`: {WinRate: 52, Frequency: 40},
+		`3075`: {WinRate: 51, Frequency: 30},
+	}
+	data.Items.Counter = map[string]StatItem{
+		`3075`: {WinRate: 53, Frequency: 10},
+	}
+
+	cases := []struct {
+		version     string
+		items       map[string]common.BuildItem
+		boots       []string
+		situational string
+		pkgName     string
+	}{
+		{
+			version: `11.5`,
+			items: map[string]common.BuildItem{
+				`3006`: {From: []string{common.BaseBootId}},
+				`3075`: {Tags: []string{`Armor`}},
+			},
+			boots:       []string{`3006`},
+			situational: `Situational: vs heavy AD`,
+			pkgName:     `murderbridge-11.5`,
+		},
+		{
+			// 3006 isn't boots & 3075 is magic resist in this item list
+			version: `11.6`,
+			items: map[string]common.BuildItem{
+				`3006`: {},
+				`3075`: {Tags: []string{`SpellBlock`}},
+			},
+			situational: `Situational: vs heavy AP`,
+			pkgName:     `murderbridge-11.6`,
+		},
+	}
+
+	// parallel subtests of the group run at the same time, each with its own importer
+	t.Run(`group`, func(t *testing.T) {
+		for _, c := range cases {
+			c := c
+			t.Run(c.version, func(t *testing.T) {
+				t.Parallel()
+
+				m := NewImporter(nil, nil, nil)
+				m.Version = c.version
+				m.items = c.items
+				for i := 0; i < 100; i++ {
+					blocks := m.makeBlocks(data)
+					if got := blockIds(blocks, `Boots`); !reflect.DeepEqual(got, c.boots) {
+						t.Fatalf("boots = %v, want %v", got, c.boots)
+					}
+					if got := blockIds(blocks, c.situational); !reflect.DeepEqual(got, []string{`3075`}) {
+						t.Fatalf("%s = %v, want [3075]", c.situational, got)
+					}
+					if got := getPkgName(DefaultGameType, m.Version, m.scorer.Name()); got != c.pkgName {
+						t.Fatalf("package name = %q, want %q", got, c.pkgName)
+					}
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,117 @@
+package murderbridge
+
+import (
+	"data-crawler/pkg/common"
+	"fmt"
+	"math"
+)
+
+const (
+	DefaultScorer = `default`
+	wilsonZ       = 1.96
+	priorGames    = 100
+)
+
+type Scorer interface {
+	Score(s StatItem) float64
+	// Name is used in package names & titles when it's not the default one
+	Name() string
+}
+
+// gamesScorer needs `Ratio` of every entry, entries without it would all tie.
+type gamesScorer interface {
+	needsGames()
+}
+
+// LogisticScorer is the hand-tuned formula, weights win rate by a logistic curve of frequency.
+type LogisticScorer struct{}
+
+// WilsonScorer ranks by the lower bound of Wilson score interval of win rate.
+type WilsonScorer struct {
+	Z float64
+}
+
+// BayesianScorer shrinks win rate towards a prior, items with few games stay close to it.
+type BayesianScorer struct {
+	PriorWinRate float64
+	PriorGames   float64
+}
+
+var scorers = map[string]Scorer{
+	DefaultScorer: LogisticScorer{},
+	`wilson`:      WilsonScorer{Z: wilsonZ},
+	`bayes`:       BayesianScorer{PriorWinRate: generalRatio, PriorGames: priorGames},
+}
+
+func GetScorer(name string) (Scorer, error) {
+	s, ok := scorers[name]
+	if !ok {
+		return nil, fmt.Errorf("murderbridge: unknown scorer %s", name)
+	}
+
+	return s, nil
+}
+
+// ratio is [wins, games]
+func (s StatItem) games() float64 {
+	if len(s.Ratio) < 2 {
+		return 0
+	}
+	return float64(s.Ratio[1])
+}
+
+// pickScorer falls back to the default scorer if `data` lacks games, so a ranking never mixes both.
+func pickScorer(scorer Scorer, data map[string]StatItem) (Scorer, bool) {
+	if _, ok := scorer.(gamesScorer); !ok {
+		return scorer, false
+	}
+
+	for _, v := range data {
+		if v.games() == 0 {
+			return LogisticScorer{}, true
+		}
+	}
+	return scorer, false
+}
+
+func (LogisticScorer) Name() string {
+	return DefaultScorer
+}
+
+func (WilsonScorer) Name() string {
+	return `wilson`
+}
+
+func (BayesianScorer) Name() string {
+	return `bayes`
+}
+
+func (WilsonScorer) needsGames() {}
+
+func (BayesianScorer) needsGames() {}
+
+func (LogisticScorer) Score(s StatItem) float64 {
+	return scorer(s.WinRate, s.Frequency)
+}
+
+func (w WilsonScorer) Score(s StatItem) float64 {
+	n := s.games()
+	if n == 0 {
+		return 0
+	}
+
+	p := s.WinRate / 100
+	z2 := w.Z * w.Z
+	center := p + z2/(2*n)
+	margin := w.Z * math.Sqrt(p*(1-p)/n+z2/(4*n*n))
+	return (center - margin) / (1 + z2/n) * 100
+}
+
+func (b BayesianScorer) Score(s StatItem) float64 {
+	n := s.games()
+	if n == 0 {
+		return 0
+	}
+
+	return common.ShrinkWinRate(s.WinRate, n, b.PriorWinRate, b.PriorGames)
+}
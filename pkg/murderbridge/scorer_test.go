@@ -0,0 +1,57 @@
+package murderbridge
+
+import (
+	"testing"
+)
+
+func TestScorers(t *testing.T) {
+	popular := StatItem{WinRate: 55, Ratio: []int{550, 1000}, Frequency: 20}
+	rare := StatItem{WinRate: 70, Ratio: []int{7, 10}, Frequency: 0.2}
+
+	for _, name := range []string{DefaultScorer, `wilson`, `bayes`} {
+		s, err := GetScorer(name)
+		if err != nil {
+			t.Fatalf("GetScorer(%q) failed: %s", name, err)
+		}
+		if s.Name() != name {
+			t.Errorf("GetScorer(%q).Name() = %q", name, s.Name())
+		}
+		if s.Score(popular) <= s.Score(rare) {
+			t.Errorf("%s: popular item scored %f, not above rare one %f", name, s.Score(popular), s.Score(rare))
+		}
+	}
+
+	if _, err := GetScorer(`unknown`); err == nil {
+		t.Errorf("GetScorer(unknown) should fail")
+	}
+}
+
+func TestPickScorer(t *testing.T) {
+	withGames := map[string]StatItem{
+		`a`: {WinRate: 55, Ratio: []int{55, 100}, Frequency: 10},
+	}
+	withoutGames := map[string]StatItem{
+		`a`: {WinRate: 55, Ratio: []int{55, 100}, Frequency: 10},
+		`b`: {WinRate: 60, Frequency: 5},
+	}
+	wilson := WilsonScorer{Z: wilsonZ}
+
+	cases := []struct {
+		name     string
+		scorer   Scorer
+		data     map[string]StatItem
+		want     string
+		fellBack bool
+	}{
+		{name: `wilson with games`, scorer: wilson, data: withGames, want: `wilson`},
+		{name: `wilson without games`, scorer: wilson, data: withoutGames, want: DefaultScorer, fellBack: true},
+		{name: `default without games`, scorer: LogisticScorer{}, data: withoutGames, want: DefaultScorer},
+	}
+
+	for _, c := range cases {
+		s, fellBack := pickScorer(c.scorer, c.data)
+		if s.Name() != c.want || fellBack != c.fellBack {
+			t.Errorf("%s: pickScorer() = %s, %v, want %s, %v", c.name, s.Name(), fellBack, c.want, c.fellBack)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package murderbridge
+
+import (
+	"data-crawler/pkg/common"
+	"strings"
+)
+
+const situationalItems = 3
+
+type situation struct {
+	title string
+	match func(item common.BuildItem) bool
+}
+
+func hasTag(tag string) func(item common.BuildItem) bool {
+	return func(item common.BuildItem) bool {
+		return common.Includes(tag, item.Tags)
+	}
+}
+
+// situations are matched by item tags & descriptions from Data Dragon
+var situations = []situation{
+	{title: `Situational: vs heavy AP`, match: hasTag(`SpellBlock`)},
+	{title: `Situational: vs heavy AD`, match: hasTag(`Armor`)},
+	{title: `Situational: vs healing`, match: func(item common.BuildItem) bool {
+		return strings.Contains(item.Description, `Grievous Wounds`)
+	}},
+	{title: `Situational: vs crowd control`, match: hasTag(`Tenacity`)},
+}
+
+func (m *Importer) makeSituationalBlocks(counter map[string]StatItem) []common.ItemBuildBlockItem {
+	ranked := m.getItemList(counter, len(counter))
+
+	var blocks []common.ItemBuildBlockItem
+	for _, s := range situations {
+		var ids []string
+		for _, v := range ranked {
+			item, ok := m.items[v.RawItem]
+			if !ok || !s.match(item) {
+				continue
+			}
+
+			ids = append(ids, v.RawItem)
+			if len(ids) == situationalItems {
+				break
+			}
+		}
+
+		if len(ids) > 0 {
+			blocks = append(blocks, common.MakeBuildBlock(ids, s.title))
+		}
+	}
+
+	return blocks
+}
@@ -0,0 +1,50 @@
+package murderbridge
+
+import (
+	"data-crawler/pkg/common"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// parseAdjustments accepts a JSON object, or `key: value` pairs, e.g. `dmg_dealt: 1.05, dmg_taken: 0.95`
+func parseAdjustments(raw string) map[string]float64 {
+	adjustments := make(map[string]float64)
+	if len(strings.TrimSpace(raw)) == 0 {
+		return adjustments
+	}
+
+	if err := json.Unmarshal([]byte(raw), &adjustments); err == nil {
+		return adjustments
+	}
+
+	parts := strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == ';' || r == '\n'
+	})
+	for _, part := range parts {
+		kv := strings.SplitN(part, ":", 2)
+		if len(kv) != 2 {
+			continue
+		}
+
+		v, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(kv[1]), `%`), 64)
+		if err != nil {
+			continue
+		}
+		adjustments[strings.TrimSpace(kv[0])] = v
+	}
+
+	return adjustments
+}
+
+func makeSummary(data ChampionDataResp) *common.ChampionMeta {
+	return &common.ChampionMeta{
+		Rank:        data.Rank,
+		WinRate:     data.WinRate,
+		PickRate:    data.Frequency,
+		BanRate:     data.BanRate,
+		Games:       data.NumGames,
+		Adjustments: parseAdjustments(data.Adjustments),
+		Stats:       data.Stats,
+	}
+}
@@ -0,0 +1,24 @@
+package murderbridge
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAdjustments(t *testing.T) {
+	cases := []struct {
+		raw  string
+		want map[string]float64
+	}{
+		{raw: ``, want: map[string]float64{}},
+		{raw: `{"dmg_dealt":1.05}`, want: map[string]float64{`dmg_dealt`: 1.05}},
+		{raw: `dmg_dealt: 1.05, dmg_taken: 0.95`, want: map[string]float64{`dmg_dealt`: 1.05, `dmg_taken`: 0.95}},
+		{raw: `healing: 90%; broken`, want: map[string]float64{`healing`: 90}},
+	}
+
+	for _, c := range cases {
+		if got := parseAdjustments(c.raw); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("parseAdjustments(%q) = %v, want %v", c.raw, got, c.want)
+		}
+	}
+}
@@ -6,3 +6,12 @@ const (
 	PkgName     = `op.gg`
 	AramPkgName = `op.gg-aram`
 )
+
+// tier tables on the statistics page, by position
+var tierTables = map[string]string{
+	`top`:     `TOP`,
+	`jungle`:  `JUNGLE`,
+	`middle`:  `MID`,
+	`bottom`:  `ADC`,
+	`support`: `SUPPORT`,
+}
@@ -4,7 +4,6 @@ import (
 	"data-crawler/pkg/common"
 	"fmt"
 	"github.com/PuerkitoBio/goquery"
-	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
@@ -40,6 +39,10 @@ func genData(alias string, id int, version string) (*common.ChampionDataItem, er
 		}
 	})
 
+	if skillOrder := genSkillOrder(doc); skillOrder != nil {
+		d.SkillOrders = append(d.SkillOrders, *skillOrder)
+	}
+
 	build := common.ItemBuild{
 		Title:               "[OP.GG-ARAM] " + alias + " " + version,
 		AssociatedMaps:      []int{12},
@@ -58,39 +61,10 @@ func genData(alias string, id int, version string) (*common.ChampionDataItem, er
 		isRecommendedBuild := strings.Contains(strings.ToLower(blockType), `recommended builds`)
 
 		if isRecommendedBuild {
-			var firstBlock common.ItemBuildBlockItem
-
-			pickCnt := strings.ReplaceAll(selection.Find(`td.champion-overview__stats--pick.champion-overview__border > span`).Text(), `,`, ``)
-			winRate := selection.Find(`td.champion-overview__stats--win.champion-overview__border > strong`).Text()
-			firstBlock.Type = `Recommended build: Pick ` + pickCnt + `, Win Rate ` + winRate
-			selection.Find("li.champion-stats__list__item img").Each(func(i int, img *goquery.Selection) {
-				src, _ := img.Attr("src")
-				id := common.MatchId(src)
-				firstBlock.Items = append(firstBlock.Items, common.BlockItem{
-					Id:    id,
-					Count: 1,
-				})
-			})
-
-			build.Blocks = append(build.Blocks, firstBlock)
+			build.Blocks = append(build.Blocks, genStatBlock(selection, `Recommended build`))
 
 			selection.NextUntil(`tr.champion-overview__row--first`).Each(func(trIdx int, tr *goquery.Selection) {
-				pickCnt := strings.ReplaceAll(tr.Find(`td.champion-overview__stats--pick.champion-overview__border > span`).Text(), `,`, ``)
-				winRate := tr.Find(`td.champion-overview__stats--win.champion-overview__border > strong`).Text()
-
-				var block common.ItemBuildBlockItem
-				block.Type = `Recommended build: Pick ` + pickCnt + `, Win Rate ` + winRate
-
-				tr.Find("li.champion-stats__list__item img").Each(func(i int, img *goquery.Selection) {
-					src, _ := img.Attr("src")
-					id := common.MatchId(src)
-					block.Items = append(block.Items, common.BlockItem{
-						Id:    id,
-						Count: 1,
-					})
-				})
-
-				build.Blocks = append(build.Blocks, block)
+				build.Blocks = append(build.Blocks, genStatBlock(tr, `Recommended build`))
 			})
 
 			return
@@ -159,11 +133,11 @@ func genData(alias string, id int, version string) (*common.ChampionDataItem, er
 		sIdSrc, _ := tr.Find(`.perk-page__item--mark img`).Last().Attr(`src`)
 		runeItem.SubStyleId, _ = strconv.Atoi(common.MatchId(sIdSrc))
 
-		pickCount := tr.Find(`.champion-overview__stats--pick .pick-ratio__text`).Next().Next().Text()
-		runeItem.PickCount, _ = strconv.Atoi(strings.ReplaceAll(pickCount, `,`, ``))
-		runeItem.WinRate = tr.Find(`.champion-overview__stats--pick .win-ratio__text`).Next().Text()
+		runeItem.PickRate = parseRate(tr.Find(`.champion-overview__stats--pick .pick-ratio__text`).Next().Text())
+		runeItem.PickCount = parseCount(tr.Find(`.champion-overview__stats--pick .pick-ratio__text`).Next().Next().Text())
+		runeItem.WinRate = parseRate(tr.Find(`.champion-overview__stats--pick .win-ratio__text`).Next().Text())
 
-		runeItem.Name = "[OP.GG-ARAM] " + alias + " - " + runeItem.WinRate + ", " + fmt.Sprint(runeItem.PickCount)
+		runeItem.Name = common.StatTitle("[OP.GG-ARAM] "+alias, runeItem.PickCount, runeItem.WinRate)
 
 		d.Runes = append(d.Runes, runeItem)
 	})
@@ -248,13 +222,12 @@ listLoop:
 
 	_ = common.SaveJSON("output/index.json", allChampions)
 
-	pkg, _ := common.GenPkgInfo("tpl/package.json", common.PkgInfo{
+	_ = common.WritePkgInfo(common.PkgInfo{
 		Timestamp:       timestamp,
 		SourceVersion:   d.Version,
 		OfficialVersion: officialVer,
 		PkgName:         AramPkgName,
 	})
-	_ = ioutil.WriteFile("output/"+AramPkgName+"/package.json", []byte(pkg), 0644)
 
 	duration := time.Since(start)
 	return fmt.Sprintf("🟢 [OP.GG-ARAM] All finished, success: %d, failed: %d, took %s", cnt-failed, failed, duration)
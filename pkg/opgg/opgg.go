@@ -4,7 +4,6 @@ import (
 	"data-crawler/pkg/common"
 	"fmt"
 	"github.com/PuerkitoBio/goquery"
-	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
@@ -15,7 +14,47 @@ import (
 	"time"
 )
 
-func genPositionData(alias string, position string, id int, version string) (*common.ChampionDataItem, error) {
+func genMatchups(alias string, position string, pos string, aliasList map[string]string) []common.MatchupItem {
+	url := SourceUrl + "/" + alias + "/statistics/" + pos + "/matchup"
+
+	doc, err := common.ParseHTML(url)
+	if err != nil {
+		fmt.Printf("[OP.GG] Fetch matchups failed, %s @ %s: %s\n", alias, position, err)
+		return nil
+	}
+
+	var matchups []common.MatchupItem
+	doc.Find(`.champion-matchup-champion-list__item`).Each(func(_ int, item *goquery.Selection) {
+		name, _ := item.Attr(`data-champion-name`)
+		played, _ := item.Attr(`data-value-totalplayed`)
+		winRate, _ := item.Attr(`data-value-winrate`)
+
+		m := common.MatchupItem{
+			Alias:    aliasList[name],
+			Name:     name,
+			Position: position,
+			Games:    parseCount(played),
+			WinRate:  parseRate(winRate),
+		}
+		// win rate is a ratio in data attributes
+		if m.WinRate <= 1 {
+			m.WinRate *= 100
+		}
+		if len(m.Alias) == 0 || m.Games == 0 {
+			return
+		}
+
+		matchups = append(matchups, m)
+	})
+
+	sort.Slice(matchups, func(i, j int) bool {
+		return matchups[i].WinRate < matchups[j].WinRate
+	})
+
+	return matchups
+}
+
+func genPositionData(alias string, position string, id int, version string, aliasList map[string]string) (*common.ChampionDataItem, error) {
 	pos := position
 	if position == `middle` {
 		pos = `mid`
@@ -47,6 +86,10 @@ func genPositionData(alias string, position string, id int, version string) (*co
 		}
 	})
 
+	if skillOrder := genSkillOrder(doc); skillOrder != nil {
+		d.SkillOrders = append(d.SkillOrders, *skillOrder)
+	}
+
 	build := common.ItemBuild{
 		Title:               "[OP.GG] " + alias + " @ " + position + ` ` + version,
 		AssociatedMaps:      []int{11, 12},
@@ -65,39 +108,10 @@ func genPositionData(alias string, position string, id int, version string) (*co
 		isRecommendedBuild := strings.Contains(strings.ToLower(blockType), `recommended builds`)
 
 		if isRecommendedBuild {
-			var firstBlock common.ItemBuildBlockItem
-
-			pickCnt := strings.ReplaceAll(selection.Find(`td.champion-overview__stats--pick.champion-overview__border > span`).Text(), `,`, ``)
-			winRate := selection.Find(`td.champion-overview__stats--win.champion-overview__border > strong`).Text()
-			firstBlock.Type = `Recommended build: Pick ` + pickCnt + `, Win Rate ` + winRate
-			selection.Find("li.champion-stats__list__item img").Each(func(i int, img *goquery.Selection) {
-				src, _ := img.Attr("src")
-				id := common.MatchId(src)
-				firstBlock.Items = append(firstBlock.Items, common.BlockItem{
-					Id:    id,
-					Count: 1,
-				})
-			})
-
-			build.Blocks = append(build.Blocks, firstBlock)
+			build.Blocks = append(build.Blocks, genStatBlock(selection, `Recommended build`))
 
 			selection.NextUntil(`tr.champion-overview__row--first`).Each(func(trIdx int, tr *goquery.Selection) {
-				pickCnt := strings.ReplaceAll(tr.Find(`td.champion-overview__stats--pick.champion-overview__border > span`).Text(), `,`, ``)
-				winRate := tr.Find(`td.champion-overview__stats--win.champion-overview__border > strong`).Text()
-
-				var block common.ItemBuildBlockItem
-				block.Type = `Recommended build: Pick ` + pickCnt + `, Win Rate ` + winRate
-
-				tr.Find("li.champion-stats__list__item img").Each(func(i int, img *goquery.Selection) {
-					src, _ := img.Attr("src")
-					id := common.MatchId(src)
-					block.Items = append(block.Items, common.BlockItem{
-						Id:    id,
-						Count: 1,
-					})
-				})
-
-				build.Blocks = append(build.Blocks, block)
+				build.Blocks = append(build.Blocks, genStatBlock(tr, `Recommended build`))
 			})
 
 			return
@@ -180,11 +194,11 @@ func genPositionData(alias string, position string, id int, version string) (*co
 		sIdSrc, _ := tr.Find(`.perk-page__item--mark img`).Last().Attr(`src`)
 		runeItem.SubStyleId, _ = strconv.Atoi(common.MatchId(sIdSrc))
 
-		pickCount := tr.Find(`.champion-overview__stats--pick .pick-ratio__text`).Next().Next().Text()
-		runeItem.PickCount, _ = strconv.Atoi(strings.ReplaceAll(pickCount, `,`, ``))
-		runeItem.WinRate = tr.Find(`.champion-overview__stats--pick .win-ratio__text`).Next().Text()
+		runeItem.PickRate = parseRate(tr.Find(`.champion-overview__stats--pick .pick-ratio__text`).Next().Text())
+		runeItem.PickCount = parseCount(tr.Find(`.champion-overview__stats--pick .pick-ratio__text`).Next().Next().Text())
+		runeItem.WinRate = parseRate(tr.Find(`.champion-overview__stats--pick .win-ratio__text`).Next().Text())
 
-		runeItem.Name = "[OP.GG] " + alias + "@" + position + " - " + runeItem.WinRate + ", " + fmt.Sprint(runeItem.PickCount)
+		runeItem.Name = common.StatTitle("[OP.GG] "+alias+"@"+position, runeItem.PickCount, runeItem.WinRate)
 
 		d.Runes = append(d.Runes, runeItem)
 	})
@@ -193,17 +207,22 @@ func genPositionData(alias string, position string, id int, version string) (*co
 		return d.Runes[i].PickCount > d.Runes[j].PickCount
 	})
 
+	// counters, sorted from the hardest lane opponent.
+	// matchup page is another request, space it out like `worker` does
+	time.Sleep(time.Second * 1)
+	d.Counters = genMatchups(alias, position, pos, aliasList)
+
 	return &d, nil
 }
 
-func worker(champ ChampionListItem, position string, index int, version string) *common.ChampionDataItem {
+func worker(champ ChampionListItem, position string, index int, version string, aliasList map[string]string) *common.ChampionDataItem {
 	time.Sleep(time.Second * 1)
 
 	alias := champ.Alias
 	// fmt.Printf("⌛ [OP.GG]️️ No.%d, %s @ %s\n", index, alias, position)
 
 	id, _ := strconv.Atoi(champ.Id)
-	d, _ := genPositionData(alias, position, id, version)
+	d, _ := genPositionData(alias, position, id, version, aliasList)
 	if d != nil {
 		d.Index = index
 		d.Id = champ.Id
@@ -240,7 +259,7 @@ listLoop:
 
 			wg.Add(1)
 			go func(_cur ChampionListItem, _p string, _cnt int, _ver string) {
-				ch <- *worker(_cur, _p, _cnt, _ver)
+				ch <- *worker(_cur, _p, _cnt, _ver, aliasList)
 				wg.Done()
 			}(cur, p, cnt, d.Version)
 		}
@@ -269,15 +288,15 @@ listLoop:
 		_ = common.SaveJSON(fileName, v)
 	}
 
+	_ = common.SaveJSON(outputPath+"/tierlist.json", d.TierList)
 	_ = common.SaveJSON("output/index.json", allChampions)
 
-	pkg, _ := common.GenPkgInfo("tpl/package.json", common.PkgInfo{
+	_ = common.WritePkgInfo(common.PkgInfo{
 		Timestamp:       timestamp,
 		SourceVersion:   d.Version,
 		OfficialVersion: officialVer,
 		PkgName:         PkgName,
 	})
-	_ = ioutil.WriteFile("output/"+PkgName+"/package.json", []byte(pkg), 0644)
 
 	duration := time.Since(start)
 	return fmt.Sprintf("🟢 [OP.GG] All finished, success: %d, failed: %d, took %s", cnt-failed, failed, duration)
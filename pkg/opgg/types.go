@@ -7,8 +7,20 @@ type ChampionListItem struct {
 	Positions []string `json:"positions"`
 }
 
+type TierListItem struct {
+	Alias    string  `json:"alias"`
+	Name     string  `json:"name"`
+	Position string  `json:"position"`
+	Rank     int     `json:"rank"`
+	Tier     int     `json:"tier"`
+	WinRate  float64 `json:"winRate"`
+	PickRate float64 `json:"pickRate"`
+	BanRate  float64 `json:"banRate"`
+}
+
 type OverviewData struct {
 	Version      string             `json:"version"`
 	ChampionList []ChampionListItem `json:"championList"`
 	Unavailable  []string           `json:"unavailable"`
+	TierList     []TierListItem     `json:"tierList"`
 }
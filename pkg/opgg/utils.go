@@ -2,11 +2,106 @@ package opgg
 
 import (
 	"data-crawler/pkg/common"
+	"errors"
+	"fmt"
 	"github.com/PuerkitoBio/goquery"
 	"log"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 )
 
+var (
+	tierReg = regexp.MustCompile(`champtier-(\d+)`)
+	// patch key of the page data, other `version` keys belong to libraries & builds
+	versionReg = regexp.MustCompile(`"patch"\s*:\s*"(\d+\.\d+)"`)
+)
+
+func parseVersion(doc *goquery.Document) string {
+	verInfo := strings.Trim(doc.Find(".champion-index__version").Text(), " \n")
+	if len(verInfo) > 0 {
+		verArr := strings.Split(verInfo, ` : `)
+		return verArr[len(verArr)-1]
+	}
+
+	// fallback to page data
+	m := versionReg.FindStringSubmatch(doc.Find(`script#__NEXT_DATA__`).Text())
+	if m != nil {
+		return m[1]
+	}
+
+	return ""
+}
+
+func getSourceVersion() (string, error) {
+	doc, err := common.ParseHTML(SourceUrl + `/statistics`)
+	if err != nil {
+		return "", err
+	}
+
+	ver := parseVersion(doc)
+	if len(ver) == 0 {
+		return "", errors.New(`op.gg: patch version not found`)
+	}
+
+	return ver, nil
+}
+
+func parseRate(text string) float64 {
+	s := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(text), `%`))
+	rate, _ := strconv.ParseFloat(strings.ReplaceAll(s, `,`, ``), 64)
+	return rate
+}
+
+func parseCount(text string) int {
+	cnt, _ := strconv.Atoi(strings.ReplaceAll(strings.TrimSpace(text), `,`, ``))
+	return cnt
+}
+
+func genStatBlock(tr *goquery.Selection, name string) common.ItemBuildBlockItem {
+	block := common.NewStatBlock(
+		name,
+		parseCount(tr.Find(`td.champion-overview__stats--pick.champion-overview__border > span`).Text()),
+		parseRate(tr.Find(`td.champion-overview__stats--pick.champion-overview__border > strong`).Text()),
+		parseRate(tr.Find(`td.champion-overview__stats--win.champion-overview__border > strong`).Text()),
+	)
+
+	tr.Find("li.champion-stats__list__item img").Each(func(i int, img *goquery.Selection) {
+		src, _ := img.Attr("src")
+		id := common.MatchId(src)
+		block.Items = append(block.Items, common.BlockItem{
+			Id:    id,
+			Count: 1,
+		})
+	})
+
+	return block
+}
+
+func genSkillOrder(doc *goquery.Document) *common.SkillOrder {
+	tbody := doc.Find(`.champion-overview__table--summonerspell > tbody:last-child`)
+
+	var order []string
+	tbody.Find(`.champion-skill-build__table tr:last-child td`).Each(func(_ int, td *goquery.Selection) {
+		s := strings.ToUpper(strings.TrimSpace(td.Text()))
+		if s == `Q` || s == `W` || s == `E` || s == `R` {
+			order = append(order, s)
+		}
+	})
+	if len(order) == 0 {
+		return nil
+	}
+
+	return &common.SkillOrder{
+		Type:      `Most Frequent`,
+		Order:     order,
+		PickRate:  parseRate(tbody.Find(`td.champion-overview__stats--pick > strong`).First().Text()),
+		PickCount: parseCount(tbody.Find(`td.champion-overview__stats--pick > span`).First().Text()),
+		WinRate:   parseRate(tbody.Find(`td.champion-overview__stats--win > strong`).First().Text()),
+	}
+}
+
 func genOverview(allChampions map[string]common.ChampionItem, aliasList map[string]string, aram bool) (*OverviewData, int) {
 	url := SourceUrl
 	if aram {
@@ -18,12 +113,16 @@ func genOverview(allChampions map[string]common.ChampionItem, aliasList map[stri
 	}
 
 	d := OverviewData{
-		Version: "latest",
+		Version: parseVersion(doc),
 	}
-	if !aram {
-		verInfo := doc.Find(".champion-index__version").Text()
-		verArr := strings.Split(strings.Trim(verInfo, " \n"), ` : `)
-		d.Version = verArr[len(verArr)-1]
+	if aram && len(d.Version) == 0 {
+		// ARAM page may not show its patch, fallback to the one from SR overview
+		ver, err := getSourceVersion()
+		if err != nil {
+			fmt.Println("[OP.GG-ARAM] Detect patch version failed, use `latest`:", err)
+			ver = "latest"
+		}
+		d.Version = ver
 	}
 
 	count := 0
@@ -52,5 +151,62 @@ func genOverview(allChampions map[string]common.ChampionItem, aliasList map[stri
 		}
 	})
 
+	if !aram {
+		d.TierList = genTierList(doc, aliasList)
+	}
+
 	return &d, count
 }
+
+func genTierList(doc *goquery.Document, aliasList map[string]string) []TierListItem {
+	var tierList []TierListItem
+
+	for position, tab := range tierTables {
+		doc.Find(`tbody.champion-trend-tier-` + tab + ` tr`).Each(func(_ int, tr *goquery.Selection) {
+			name := strings.TrimSpace(tr.Find(`.champion-index-table__name`).Text())
+			alias := aliasList[name]
+			if len(alias) == 0 {
+				return
+			}
+
+			item := TierListItem{
+				Alias:    alias,
+				Name:     name,
+				Position: position,
+				Rank:     parseCount(tr.Find(`td.champion-index-table__cell--rank`).Text()),
+			}
+
+			// tier image cell may sit anywhere among value cells, count rate columns on their own
+			col := 0
+			tr.Find(`td.champion-index-table__cell--value`).Each(func(_ int, td *goquery.Selection) {
+				if src, ok := td.Find(`img`).Attr(`src`); ok {
+					if m := tierReg.FindStringSubmatch(src); m != nil {
+						item.Tier, _ = strconv.Atoi(m[1])
+					}
+					return
+				}
+
+				switch col {
+				case 0:
+					item.WinRate = parseRate(td.Text())
+				case 1:
+					item.PickRate = parseRate(td.Text())
+				case 2:
+					item.BanRate = parseRate(td.Text())
+				}
+				col += 1
+			})
+
+			tierList = append(tierList, item)
+		})
+	}
+
+	sort.Slice(tierList, func(i, j int) bool {
+		if tierList[i].Position != tierList[j].Position {
+			return tierList[i].Position < tierList[j].Position
+		}
+		return tierList[i].Rank < tierList[j].Rank
+	})
+
+	return tierList
+}
@@ -24,12 +24,18 @@ log() {
 
 log "pwd is $workDir"
 
-declare -a arr=("op.gg" "op.gg-aram" "murderbridge" "lolalytics" "lolalytics-aram")
-
 ./data-crawler $args
 
 cd "$workDir" || return
 
+if [ ! -f "$workDir/output/packages.txt" ]; then
+  log "no package list, crawler failed?"
+  exit 1
+fi
+
+# packages written by this run
+mapfile -t arr < "$workDir/output/packages.txt"
+
 for i in "${arr[@]}"; do
   publish "$i"
 done